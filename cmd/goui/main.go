@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: MIT
+
+// Command goui is the command line tool for goui applications.
+//
+//	goui gen [flags] definition...
+//	goui lint [flags] definition...
+//
+// gen generates typed Go code for view definitions, see the gen package. lint
+// checks view definitions and the code using them, see the lint package.
+//
+// The tool knows the elements of goui. Applications with their own elements
+// (or with lint probes) build their own command, importing the packages
+// registering them and calling gen.Run or lint.Run.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/mheremans/goui/gen"
+	_ "github.com/mheremans/goui/layout"
+	"github.com/mheremans/goui/lint"
+	_ "github.com/mheremans/goui/widget"
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: goui <command> [arguments]\n\n")
+	fmt.Fprintf(os.Stderr, "commands:\n")
+	fmt.Fprintf(os.Stderr, "\tgen\tgenerate typed Go code for view definitions\n")
+	fmt.Fprintf(os.Stderr, "\tlint\tcheck view definitions and the code using them\n")
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "gen":
+		err = gen.Run(os.Args[2:], os.Stdout, os.Stderr)
+	case "lint":
+		err = lint.Run(os.Args[2:], os.Stdout, os.Stderr)
+	case "help", "-h", "-help", "--help":
+		usage()
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "goui: unknown command %q\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "goui: %v\n", err)
+		os.Exit(1)
+	}
+}
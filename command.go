@@ -0,0 +1,164 @@
+// SPDX-License-Identifier: MIT
+
+package goui
+
+import (
+	"embed"
+
+	"gioui.org/layout"
+	"github.com/mheremans/goui/types"
+)
+
+// CommandFn is the function executed by a Command
+type CommandFn = func(types.Context)
+
+// Command implements types.Command.
+//
+// A Command can be executed when its optional canExecute binding is true and,
+// for an async command, it is not already running. Export it with
+// View.ExportCommand to reference it from a definition:
+//
+//	type: widget.Button
+//	command: <string>	# name of the command
+type Command struct {
+	name       string
+	execute    CommandFn
+	async      bool
+	isRunning  *types.Binding[bool]
+	canExecute *types.ComputedBinding[bool]
+}
+
+// NewCommand creates a new Command that runs execute on the event loop.
+//
+// Parameters:
+// - name: the name of the command, used to reference it from definitions.
+// - execute: the function executed by the command.
+// - canExecute: (Optional) binding that enables or disables the command.
+//
+// Returns:
+// - *Command: a pointer to the newly created Command.
+func NewCommand(
+	name string,
+	execute CommandFn,
+	canExecute ...types.ValueBinding[bool],
+) *Command {
+	return newCommand(name, execute, false, canExecute...)
+}
+
+// NewAsyncCommand creates a new Command that runs execute on a new goroutine.
+// While it is running, IsRunning is true and the command can not be executed
+// again.
+//
+// execute receives a copy of the context that does not depend on the frame
+// in which the command was started: it holds the window and the view, its
+// layout context (Gtx) is empty. Results are sent back to the event loop with
+// Window.Dispatch; bindings set by execute do so by themselves.
+func NewAsyncCommand(
+	name string,
+	execute CommandFn,
+	canExecute ...types.ValueBinding[bool],
+) *Command {
+	return newCommand(name, execute, true, canExecute...)
+}
+
+func newCommand(
+	name string,
+	execute CommandFn,
+	async bool,
+	canExecute ...types.ValueBinding[bool],
+) *Command {
+	c := &Command{
+		name:      name,
+		execute:   execute,
+		async:     async,
+		isRunning: types.NewBinding(name+" IsRunning", false),
+	}
+
+	sources := []types.Bindable{c.isRunning}
+	for _, b := range canExecute {
+		sources = append(sources, b)
+	}
+	c.canExecute = types.NewComputedBinding(name+" CanExecute", func() bool {
+		if c.isRunning.Get() {
+			return false
+		}
+		for _, b := range canExecute {
+			if !b.Get() {
+				return false
+			}
+		}
+		return true
+	}, sources...)
+	return c
+}
+
+func (c Command) Name() string {
+	return c.name
+}
+
+// CanExecute returns a binding that is true while the command can be
+// executed.
+func (c Command) CanExecute() types.ValueBinding[bool] {
+	return c.canExecute
+}
+
+// IsRunning returns a binding that is true while an async command is running.
+// It is always false for a synchronous command.
+func (c Command) IsRunning() *types.Binding[bool] {
+	return c.isRunning
+}
+
+// Execute executes the command, unless it can not be executed right now.
+func (c *Command) Execute(ctx types.Context) {
+	if !c.canExecute.Get() {
+		return
+	}
+
+	if !c.async {
+		c.execute(ctx)
+		return
+	}
+
+	c.isRunning.Set(true)
+	async := &asyncContext{
+		window:   ctx.Window(),
+		view:     ctx.View(),
+		fontsDir: ctx.FontsDir(),
+	}
+	go func() {
+		defer c.isRunning.Set(false)
+		c.execute(async)
+	}()
+}
+
+// asyncContext is the context of an async command, see NewAsyncCommand.
+type asyncContext struct {
+	window   types.Window
+	view     types.View
+	fontsDir *embed.FS
+}
+
+func (c *asyncContext) Window() types.Window {
+	return c.window
+}
+
+func (c *asyncContext) View() types.View {
+	return c.view
+}
+
+// Gtx returns an empty layout context, there is no frame to draw in.
+func (c *asyncContext) Gtx() layout.Context {
+	return layout.Context{}
+}
+
+func (c *asyncContext) FontsDir() *embed.FS {
+	return c.fontsDir
+}
+
+func (c *asyncContext) SetFontsDir(fs *embed.FS) {
+	c.fontsDir = fs
+}
+
+func (c *asyncContext) SetView(v types.View) {
+	c.view = v
+}
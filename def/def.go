@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: MIT
+
+// Package def builds definitions in Go code, as an alternative to definition
+// files. Every element has a typed builder with a method per property:
+//
+//	root := def.Flex(layout.Vertical).Children(
+//		def.Label("@title").Format(widget.H4),
+//		def.Input().ID("timeInput").Hint("@minutes").Bind("Time Remaining"),
+//		def.Button("Start").Command("toggleBoiling").Enabled("!Boiling"),
+//	)
+//	d, err := definition.Build(ctx, "timer", root)
+//
+// Bindings and functions are referenced by name and resolved through the view,
+// and text properties accept expressions and translated messages, like in a
+// definition file. Build creates the same elements and id index as a
+// definition file would.
+package def
+
+import (
+	"gioui.org/text"
+	"github.com/mheremans/goui/definition"
+)
+
+// Node is an element built in code.
+type Node = definition.Node
+
+// builder holds the properties common to all elements. B is the type of the
+// typed builder embedding it, which the methods return for chaining.
+type builder[B any] struct {
+	elem *definition.Element
+	self B
+}
+
+func newBuilder[B any](self B, typeName string) builder[B] {
+	return builder[B]{elem: definition.NewElement(typeName), self: self}
+}
+
+// Element returns the element definition built.
+func (b builder[B]) Element() *definition.Element {
+	return b.elem
+}
+
+// ID sets the id of the element.
+func (b builder[B]) ID(id string) B {
+	return b.Set("id", id)
+}
+
+// Weight sets the weight of the element in its layout.
+func (b builder[B]) Weight(weight float32) B {
+	return b.Set("weight", weight)
+}
+
+// Visible shows or hides the element according to the boolean binding ref
+// (prefixed with ! to invert) or expression.
+func (b builder[B]) Visible(ref string) B {
+	return b.Set("visible", ref)
+}
+
+// Enabled enables or disables the element according to the boolean binding
+// ref (prefixed with ! to invert) or expression.
+func (b builder[B]) Enabled(ref string) B {
+	return b.Set("enabled", ref)
+}
+
+// Set sets a property that has no typed method, e.g. a translated message
+// with arguments (see Tr).
+func (b builder[B]) Set(name string, value any) B {
+	b.elem.Set(name, value)
+	return b.self
+}
+
+// pointerBuilder adds the pointer event callbacks of widgets.
+type pointerBuilder[B any] struct {
+	builder[B]
+}
+
+// OnHovered sets the function called while hovering over the element.
+func (b pointerBuilder[B]) OnHovered(fn string) B {
+	return b.Set("onHovered", fn)
+}
+
+// OnHoverEntered sets the function called when the pointer enters the
+// element.
+func (b pointerBuilder[B]) OnHoverEntered(fn string) B {
+	return b.Set("onHoverEntered", fn)
+}
+
+// OnHoverExited sets the function called when the pointer leaves the element.
+func (b pointerBuilder[B]) OnHoverExited(fn string) B {
+	return b.Set("onHoverExited", fn)
+}
+
+// OnPressed sets the function called while pressing the element.
+func (b pointerBuilder[B]) OnPressed(fn string) B {
+	return b.Set("onPressed", fn)
+}
+
+// OnPressDown sets the function called when the element is pressed down.
+func (b pointerBuilder[B]) OnPressDown(fn string) B {
+	return b.Set("onPressDown", fn)
+}
+
+// OnPressUp sets the function called when the element is released.
+func (b pointerBuilder[B]) OnPressUp(fn string) B {
+	return b.Set("onPressUp", fn)
+}
+
+// Tr returns a translated message with arguments, to be set with Set (see
+// definition.IsTranslation). String arguments reference bindings. Messages
+// without arguments can be used as "@key" in text properties.
+func Tr(key string, args map[string]any) map[string]any {
+	tr := map[string]any{"tr": key}
+	if len(args) > 0 {
+		tr["args"] = args
+	}
+	return tr
+}
+
+// wrapPolicyName returns the definition name of a text wrap policy.
+func wrapPolicyName(policy text.WrapPolicy) string {
+	switch policy {
+	case text.WrapWords:
+		return "WrapWords"
+	case text.WrapGraphemes:
+		return "WrapGraphemes"
+	default:
+		return "WrapHeuristically"
+	}
+}
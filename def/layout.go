@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: MIT
+
+package def
+
+import (
+	giolayout "gioui.org/layout"
+	"github.com/mheremans/goui/definition"
+)
+
+// FlexBuilder builds a layout.Flex.
+type FlexBuilder struct {
+	builder[*FlexBuilder]
+}
+
+// Flex builds a layout.Flex laying out its children along axis.
+func Flex(axis giolayout.Axis) *FlexBuilder {
+	b := new(FlexBuilder)
+	b.builder = newBuilder(b, "layout.Flex")
+	return b.Set("axis", definition.GioConstantName(axis))
+}
+
+func (b *FlexBuilder) Spacing(spacing giolayout.Spacing) *FlexBuilder {
+	return b.Set("spacing", definition.GioConstantName(spacing))
+}
+
+func (b *FlexBuilder) Alignment(alignment giolayout.Alignment) *FlexBuilder {
+	return b.Set("alignment", definition.GioConstantName(alignment))
+}
+
+// Children adds children to the layout.
+func (b *FlexBuilder) Children(children ...Node) *FlexBuilder {
+	b.elem.Children(children...)
+	return b
+}
+
+// InsetBuilder builds a layout.Inset.
+type InsetBuilder struct {
+	builder[*InsetBuilder]
+}
+
+// Inset builds a layout.Inset around child.
+func Inset(child Node) *InsetBuilder {
+	b := new(InsetBuilder)
+	b.builder = newBuilder(b, "layout.Inset")
+	return b.Set("child", child)
+}
+
+func (b *InsetBuilder) Top(top float32) *InsetBuilder {
+	return b.Set("top", top)
+}
+
+func (b *InsetBuilder) Bottom(bottom float32) *InsetBuilder {
+	return b.Set("bottom", bottom)
+}
+
+func (b *InsetBuilder) Left(left float32) *InsetBuilder {
+	return b.Set("left", left)
+}
+
+func (b *InsetBuilder) Right(right float32) *InsetBuilder {
+	return b.Set("right", right)
+}
+
+// Uniform sets the same inset on all sides.
+func (b *InsetBuilder) Uniform(inset float32) *InsetBuilder {
+	return b.Top(inset).Bottom(inset).Left(inset).Right(inset)
+}
+
+// MinSizeBuilder builds a layout.MinSize.
+type MinSizeBuilder struct {
+	builder[*MinSizeBuilder]
+}
+
+// MinSize builds a layout.MinSize around child.
+func MinSize(child Node) *MinSizeBuilder {
+	b := new(MinSizeBuilder)
+	b.builder = newBuilder(b, "layout.MinSize")
+	return b.Set("child", child)
+}
+
+func (b *MinSizeBuilder) MinWidth(width float32) *MinSizeBuilder {
+	return b.Set("minWidth", width)
+}
+
+func (b *MinSizeBuilder) MinHeight(height float32) *MinSizeBuilder {
+	return b.Set("minHeight", height)
+}
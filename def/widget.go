@@ -0,0 +1,371 @@
+// SPDX-License-Identifier: MIT
+
+package def
+
+import (
+	"gioui.org/font"
+	giolayout "gioui.org/layout"
+	"gioui.org/text"
+	"github.com/mheremans/goui/definition"
+	"github.com/mheremans/goui/widget"
+)
+
+// LabelBuilder builds a widget.Label.
+type LabelBuilder struct {
+	builder[*LabelBuilder]
+}
+
+// Label builds a widget.Label showing text, which may be an expression or a
+// translated message.
+func Label(txt string) *LabelBuilder {
+	b := new(LabelBuilder)
+	b.builder = newBuilder(b, "widget.Label")
+	return b.Set("text", txt)
+}
+
+func (b *LabelBuilder) Format(format widget.LabelFormat) *LabelBuilder {
+	return b.Set("labelFormat", format.String())
+}
+
+func (b *LabelBuilder) Alignment(alignment text.Alignment) *LabelBuilder {
+	return b.Set("alignment", definition.GioConstantName(alignment))
+}
+
+func (b *LabelBuilder) MaxLines(maxLines int) *LabelBuilder {
+	return b.Set("maxLines", maxLines)
+}
+
+func (b *LabelBuilder) WrapPolicy(policy text.WrapPolicy) *LabelBuilder {
+	return b.Set("wrapPolicy", wrapPolicyName(policy))
+}
+
+func (b *LabelBuilder) Truncator(truncator string) *LabelBuilder {
+	return b.Set("truncator", truncator)
+}
+
+func (b *LabelBuilder) LineHeight(lineHeight float32) *LabelBuilder {
+	return b.Set("lineHeight", lineHeight)
+}
+
+func (b *LabelBuilder) LineHeightScale(scale float32) *LabelBuilder {
+	return b.Set("lineHeightScale", scale)
+}
+
+// Font sets the font, with its style and weight (e.g. "Bold").
+func (b *LabelBuilder) Font(name string, style font.Style, weight string) *LabelBuilder {
+	return b.Set("font", name).Set("fontStyle", definition.GioConstantName(style)).Set("fontWeight", weight)
+}
+
+// Color sets the text color, a color name or hex value (see colors.GetColor).
+func (b *LabelBuilder) Color(color string) *LabelBuilder {
+	return b.Set("color", color)
+}
+
+func (b *LabelBuilder) SelectionColor(color string) *LabelBuilder {
+	return b.Set("selectionColor", color)
+}
+
+// Bind binds the text to the string binding ref.
+func (b *LabelBuilder) Bind(ref string) *LabelBuilder {
+	return b.Set("binding", ref)
+}
+
+// ButtonBuilder builds a widget.Button.
+type ButtonBuilder struct {
+	pointerBuilder[*ButtonBuilder]
+}
+
+// Button builds a widget.Button with label, which may be an expression or a
+// translated message.
+func Button(label string) *ButtonBuilder {
+	b := new(ButtonBuilder)
+	b.builder = newBuilder(b, "widget.Button")
+	return b.Set("label", label)
+}
+
+// Bind binds the label to the string binding ref.
+func (b *ButtonBuilder) Bind(ref string) *ButtonBuilder {
+	return b.Set("binding", ref)
+}
+
+// Command sets the command executed when the button is clicked.
+func (b *ButtonBuilder) Command(command string) *ButtonBuilder {
+	return b.Set("command", command)
+}
+
+// OnClicked sets the function called when the button is clicked.
+func (b *ButtonBuilder) OnClicked(fn string) *ButtonBuilder {
+	return b.Set("onClicked", fn)
+}
+
+// IconButtonBuilder builds a widget.IconButton.
+type IconButtonBuilder struct {
+	pointerBuilder[*IconButtonBuilder]
+}
+
+// IconButton builds a widget.IconButton with icon (see icons.Icon) and
+// description, which may be an expression or a translated message.
+func IconButton(icon string, description string) *IconButtonBuilder {
+	b := new(IconButtonBuilder)
+	b.builder = newBuilder(b, "widget.IconButton")
+	return b.Set("icon", icon).Set("description", description)
+}
+
+// BindDescription binds the description to the string binding ref.
+func (b *IconButtonBuilder) BindDescription(ref string) *IconButtonBuilder {
+	return b.Set("descriptionBinding", ref)
+}
+
+// Command sets the command executed when the button is clicked.
+func (b *IconButtonBuilder) Command(command string) *IconButtonBuilder {
+	return b.Set("command", command)
+}
+
+// OnClicked sets the function called when the button is clicked.
+func (b *IconButtonBuilder) OnClicked(fn string) *IconButtonBuilder {
+	return b.Set("onClicked", fn)
+}
+
+// InputBuilder builds a widget.Input.
+type InputBuilder struct {
+	builder[*InputBuilder]
+}
+
+// Input builds a widget.Input.
+func Input() *InputBuilder {
+	b := new(InputBuilder)
+	b.builder = newBuilder(b, "widget.Input")
+	return b
+}
+
+// Hint sets the hint, which may be an expression or a translated message.
+func (b *InputBuilder) Hint(hint string) *InputBuilder {
+	return b.Set("hint", hint)
+}
+
+// BindHint binds the hint to the string binding ref.
+func (b *InputBuilder) BindHint(ref string) *InputBuilder {
+	return b.Set("hintBinding", ref)
+}
+
+func (b *InputBuilder) Alignment(alignment text.Alignment) *InputBuilder {
+	return b.Set("alignment", definition.GioConstantName(alignment))
+}
+
+func (b *InputBuilder) HelperText(helperText string) *InputBuilder {
+	return b.Set("helperText", helperText)
+}
+
+func (b *InputBuilder) MultiLine(multiLine bool) *InputBuilder {
+	return b.Set("multiLine", multiLine)
+}
+
+func (b *InputBuilder) ReadOnly(readOnly bool) *InputBuilder {
+	return b.Set("readOnly", readOnly)
+}
+
+func (b *InputBuilder) Submit(submit bool) *InputBuilder {
+	return b.Set("submit", submit)
+}
+
+func (b *InputBuilder) Mask(mask rune) *InputBuilder {
+	return b.Set("mask", string(mask))
+}
+
+func (b *InputBuilder) MaxLen(maxLen int) *InputBuilder {
+	return b.Set("maxLen", maxLen)
+}
+
+func (b *InputBuilder) InputType(inputType widget.InputType) *InputBuilder {
+	return b.Set("inputType", inputType.String())
+}
+
+func (b *InputBuilder) WrapPolicy(policy text.WrapPolicy) *InputBuilder {
+	return b.Set("wrapPolicy", wrapPolicyName(policy))
+}
+
+// FilterCallback sets the function adjusting the filter to the input.
+func (b *InputBuilder) FilterCallback(fn string) *InputBuilder {
+	return b.Set("filterCallback", fn)
+}
+
+// Bind binds the text to the string binding ref.
+func (b *InputBuilder) Bind(ref string) *InputBuilder {
+	return b.Set("binding", ref)
+}
+
+// Converter sets the converter adapting a binding of another type.
+func (b *InputBuilder) Converter(converter string) *InputBuilder {
+	return b.Set("converter", converter)
+}
+
+// CheckBoxBuilder builds a widget.CheckBox.
+type CheckBoxBuilder struct {
+	pointerBuilder[*CheckBoxBuilder]
+}
+
+// CheckBox builds a widget.CheckBox with label, which may be an expression or
+// a translated message.
+func CheckBox(label string) *CheckBoxBuilder {
+	b := new(CheckBoxBuilder)
+	b.builder = newBuilder(b, "widget.CheckBox")
+	return b.Set("label", label)
+}
+
+// BindLabel binds the label to the string binding ref.
+func (b *CheckBoxBuilder) BindLabel(ref string) *CheckBoxBuilder {
+	return b.Set("labelBinding", ref)
+}
+
+func (b *CheckBoxBuilder) Value(value bool) *CheckBoxBuilder {
+	return b.Set("value", value)
+}
+
+func (b *CheckBoxBuilder) HelperText(helperText string) *CheckBoxBuilder {
+	return b.Set("helperText", helperText)
+}
+
+// Bind binds the value to the boolean binding ref.
+func (b *CheckBoxBuilder) Bind(ref string) *CheckBoxBuilder {
+	return b.Set("binding", ref)
+}
+
+// SliderBuilder builds a widget.Slider.
+type SliderBuilder struct {
+	builder[*SliderBuilder]
+}
+
+// Slider builds a widget.Slider.
+func Slider() *SliderBuilder {
+	b := new(SliderBuilder)
+	b.builder = newBuilder(b, "widget.Slider")
+	return b
+}
+
+func (b *SliderBuilder) Axis(axis giolayout.Axis) *SliderBuilder {
+	return b.Set("axis", definition.GioConstantName(axis))
+}
+
+func (b *SliderBuilder) Color(color string) *SliderBuilder {
+	return b.Set("color", color)
+}
+
+func (b *SliderBuilder) HelperText(helperText string) *SliderBuilder {
+	return b.Set("helperText", helperText)
+}
+
+// Bind binds the value to the float32 binding ref.
+func (b *SliderBuilder) Bind(ref string) *SliderBuilder {
+	return b.Set("binding", ref)
+}
+
+// Converter sets the converter adapting a binding of another type.
+func (b *SliderBuilder) Converter(converter string) *SliderBuilder {
+	return b.Set("converter", converter)
+}
+
+// ProgressBarBuilder builds a widget.ProgressBar.
+type ProgressBarBuilder struct {
+	builder[*ProgressBarBuilder]
+}
+
+// ProgressBar builds a widget.ProgressBar.
+func ProgressBar() *ProgressBarBuilder {
+	b := new(ProgressBarBuilder)
+	b.builder = newBuilder(b, "widget.ProgressBar")
+	return b
+}
+
+func (b *ProgressBarBuilder) Value(value float32) *ProgressBarBuilder {
+	return b.Set("value", value)
+}
+
+// Bind binds the value to the float32 binding ref.
+func (b *ProgressBarBuilder) Bind(ref string) *ProgressBarBuilder {
+	return b.Set("binding", ref)
+}
+
+// Converter sets the converter adapting a binding of another type.
+func (b *ProgressBarBuilder) Converter(converter string) *ProgressBarBuilder {
+	return b.Set("converter", converter)
+}
+
+// LoaderBuilder builds a widget.Loader.
+type LoaderBuilder struct {
+	builder[*LoaderBuilder]
+}
+
+// Loader builds a widget.Loader.
+func Loader() *LoaderBuilder {
+	b := new(LoaderBuilder)
+	b.builder = newBuilder(b, "widget.Loader")
+	return b
+}
+
+func (b *LoaderBuilder) Color(color string) *LoaderBuilder {
+	return b.Set("color", color)
+}
+
+// GraphicBuilder builds a widget.Graphic.
+type GraphicBuilder struct {
+	builder[*GraphicBuilder]
+}
+
+// Graphic builds a widget.Graphic drawn by the function drawFunction.
+func Graphic(drawFunction string) *GraphicBuilder {
+	b := new(GraphicBuilder)
+	b.builder = newBuilder(b, "widget.Graphic")
+	return b.Set("drawFunction", drawFunction)
+}
+
+// SpacerBuilder builds a widget.Spacer.
+type SpacerBuilder struct {
+	builder[*SpacerBuilder]
+}
+
+// Spacer builds a widget.Spacer of the given size.
+func Spacer(width float32, height float32) *SpacerBuilder {
+	b := new(SpacerBuilder)
+	b.builder = newBuilder(b, "widget.Spacer")
+	return b.Set("width", width).Set("height", height)
+}
+
+// ListBuilder builds a widget.List.
+type ListBuilder struct {
+	builder[*ListBuilder]
+}
+
+// List builds a widget.List of the items of the list binding ref.
+func List(ref string) *ListBuilder {
+	b := new(ListBuilder)
+	b.builder = newBuilder(b, "widget.List")
+	return b.Set("binding", ref)
+}
+
+func (b *ListBuilder) Axis(axis giolayout.Axis) *ListBuilder {
+	return b.Set("axis", definition.GioConstantName(axis))
+}
+
+func (b *ListBuilder) Alignment(alignment giolayout.Alignment) *ListBuilder {
+	return b.Set("alignment", definition.GioConstantName(alignment))
+}
+
+func (b *ListBuilder) ScrollToEnd(scrollToEnd bool) *ListBuilder {
+	return b.Set("scrollToEnd", scrollToEnd)
+}
+
+// Template sets the element created for every item, see
+// definition.ItemContext.
+func (b *ListBuilder) Template(template Node) *ListBuilder {
+	return b.Set("template", template)
+}
+
+// ItemRenderer sets the function rendering the items, instead of a template.
+func (b *ListBuilder) ItemRenderer(fn string) *ListBuilder {
+	return b.Set("itemRenderer", fn)
+}
+
+// ItemEventHandler sets the function handling the events of the items.
+func (b *ListBuilder) ItemEventHandler(fn string) *ListBuilder {
+	return b.Set("itemEventHandler", fn)
+}
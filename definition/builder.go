@@ -0,0 +1,164 @@
+// SPDX-License-Identifier: MIT
+
+package definition
+
+import (
+	"fmt"
+
+	"github.com/mheremans/goui/types"
+	"gopkg.in/yaml.v3"
+)
+
+// Node is an element of a definition built in code, see Element and the typed
+// builders of package def.
+type Node interface {
+	Element() *Element
+}
+
+// Element is the definition of an element built in code instead of decoded
+// from a definition file. It holds the same properties as the element in a
+// definition file, e.g.:
+//
+//	definition.NewElement("layout.Flex").
+//		Set("axis", "Vertical").
+//		Children(
+//			definition.NewElement("widget.Label").Set("text", "@title"),
+//			definition.NewElement("widget.Button").ID("start").Set("command", "start"),
+//		)
+//
+// Element trees are created with Build, which expands, validates and creates
+// them like a definition file, so bindings, functions, expressions and
+// translations are wired the same way.
+type Element struct {
+	typeName string
+	names    []string
+	values   map[string]any
+}
+
+// NewElement creates the definition of an element of type typeName, e.g.
+// "widget.Label".
+func NewElement(typeName string) *Element {
+	return &Element{typeName: typeName, values: make(map[string]any)}
+}
+
+// Element returns e itself, so Elements can be used as Nodes.
+func (e *Element) Element() *Element {
+	return e
+}
+
+func (e Element) Type() string {
+	return e.typeName
+}
+
+// Set sets the property name. Values are scalars, mappings (e.g. a translated
+// message, see IsTranslation), Nodes for elements (e.g. `template`) and
+// []Node for lists of elements.
+func (e *Element) Set(name string, value any) *Element {
+	if _, ok := e.values[name]; !ok {
+		e.names = append(e.names, name)
+	}
+	e.values[name] = value
+	return e
+}
+
+// Get returns the value of the property name.
+func (e Element) Get(name string) (any, bool) {
+	value, ok := e.values[name]
+	return value, ok
+}
+
+// ID sets the id of the element.
+func (e *Element) ID(id string) *Element {
+	return e.Set("id", id)
+}
+
+// Weight sets the weight of the element in its layout.
+func (e *Element) Weight(weight float32) *Element {
+	return e.Set("weight", weight)
+}
+
+// Visible shows or hides the element according to the boolean binding ref.
+func (e *Element) Visible(ref string) *Element {
+	return e.Set("visible", ref)
+}
+
+// Enabled enables or disables the element according to the boolean binding
+// ref.
+func (e *Element) Enabled(ref string) *Element {
+	return e.Set("enabled", ref)
+}
+
+// Child sets the child of a layout with a single child.
+func (e *Element) Child(child Node) *Element {
+	return e.Set("child", child)
+}
+
+// Children adds children to a layout.
+func (e *Element) Children(children ...Node) *Element {
+	existing, _ := e.values["children"].([]Node)
+	return e.Set("children", append(existing, children...))
+}
+
+// Node converts the element tree to YAML nodes, as decoded from a definition
+// file (see Decoder).
+func (e *Element) Node() (*yaml.Node, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	add := func(name string, value *yaml.Node) {
+		node.Content = append(node.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name}, value)
+	}
+
+	add("type", &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: e.typeName})
+	for _, name := range e.names {
+		value, err := elementValueNode(e.values[name])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s: %w", e.typeName, name, err)
+		}
+		add(name, value)
+	}
+	return node, nil
+}
+
+func elementValueNode(value any) (*yaml.Node, error) {
+	switch value := value.(type) {
+	case Node:
+		return value.Element().Node()
+	case []Node:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for _, child := range value {
+			childNode, err := child.Element().Node()
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, childNode)
+		}
+		return node, nil
+	}
+	node := new(yaml.Node)
+	if err := node.Encode(value); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// Build creates the definition of the element tree root, built in code. The
+// tree is expanded, validated and created like a definition file, name
+// identifies it in errors. Elements built in code have no position, so errors
+// have no line and column.
+func Build(ctx types.Context, name string, root Node) (*Definition, error) {
+	return BuildWithOptions(ctx, name, root, Options{})
+}
+
+// BuildWithOptions is like Build, with additional options.
+func BuildWithOptions(
+	ctx types.Context,
+	name string,
+	root Node,
+	options Options,
+) (*Definition, error) {
+	node, err := root.Element().Node()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build definition: %w", err)
+	}
+	return build(ctx, nil, name, node, options)
+}
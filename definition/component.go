@@ -0,0 +1,596 @@
+// SPDX-License-Identifier: MIT
+
+package definition
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// slotType is the type of the placeholder element in a component that is
+// replaced by the children of the component instance.
+const slotType = "slot"
+
+// component is a reusable element tree, declared under the `components` key
+// of a definition or loaded from a definition file with `include`.
+//
+//	components:
+//	  Card:
+//	    params:
+//	      title: Untitled   # default value
+//	      value:            # no default, so the parameter is required
+//	    element:
+//	      type: layout.Inset
+//	      child:
+//	        type: layout.Flex
+//	        children:
+//	          - type: widget.Label
+//	            text: $title
+//	          - type: slot
+//	  Toolbar:
+//	    include: toolbar.def.yml
+//
+// An instance is an element with the component name as type (or with an
+// `include` key instead of a type). Its properties other than id, weight,
+// child and children are the arguments for the parameters, which replace the
+// `$name` values in the component. Its children replace the slot.
+//
+// The root element of the component gets the id of the instance. The ids of
+// the other elements in the component are prefixed with the id of the
+// instance and a dot, so `ElementById("first.title")` finds the element with
+// id title in the instance with id first.
+type component struct {
+	name   string
+	params *yaml.Node // Mapping of parameter names to default values
+	body   *yaml.Node // Root element of the component
+	scope  *scope     // Scope the component was declared in
+}
+
+// scope holds the components and imports that are visible in a definition
+// file.
+type scope struct {
+	file       string
+	components map[string]*component
+	imports    map[string]string // Namespaces by prefix
+}
+
+// loadedFile is a parsed definition file, with its components and params
+// split off from the root element.
+type loadedFile struct {
+	root   *yaml.Node
+	params *yaml.Node
+	scope  *scope
+}
+
+// expander replaces the component instances and includes in a definition by
+// the elements they stand for.
+type expander struct {
+	filesystem fs.FS
+	registry   *Registry
+	origin     map[*yaml.Node]string // File each node was read from
+	files      map[string]*loadedFile
+	order      []string // Files in the order they were loaded
+	tried      []string // Included files, also those that failed to load
+	stack      []string // Components being expanded, to detect cycles
+	errs       Errors
+}
+
+func newExpander(filesystem fs.FS, registry *Registry) *expander {
+	return &expander{
+		filesystem: filesystem,
+		registry:   registry,
+		origin:     make(map[*yaml.Node]string),
+		files:      make(map[string]*loadedFile),
+	}
+}
+
+func (e *expander) errorf(node *yaml.Node, format string, args ...any) {
+	e.errs.add(&Error{
+		File:    e.origin[node],
+		Line:    node.Line,
+		Column:  node.Column,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// expandRoot expands the root element of the definition file name, that was
+// already parsed into doc.
+func (e *expander) expandRoot(name string, doc *yaml.Node) *yaml.Node {
+	file := e.addFile(name, doc)
+	if file == nil {
+		return nil
+	}
+	args := e.arguments(nil, &component{name: name, params: file.params})
+	root := clone(file.root, e.origin)
+	substitute(root, args, e.origin)
+
+	e.stack = append(e.stack, name)
+	root = e.expandElement(root, file.scope)
+	e.stack = e.stack[:len(e.stack)-1]
+	if root != nil {
+		e.checkIds(root)
+	}
+	return root
+}
+
+// loadFile reads and parses the definition file name. The file is loaded
+// only once, later calls return the same result.
+func (e *expander) loadFile(name string, at *yaml.Node) *loadedFile {
+	if file, ok := e.files[name]; ok {
+		return file
+	}
+	if !slices.Contains(e.tried, name) {
+		e.tried = append(e.tried, name)
+	}
+
+	if e.filesystem == nil {
+		e.errorf(at, "failed to include %s: no filesystem", name)
+		return nil
+	}
+	fh, err := e.filesystem.Open(name)
+	if err != nil {
+		e.errorf(at, "failed to include %s: %v", name, err)
+		return nil
+	}
+	defer fh.Close()
+
+	bytes, err := io.ReadAll(fh)
+	if err != nil {
+		e.errorf(at, "failed to include %s: %v", name, err)
+		return nil
+	}
+
+	root, err := decode(name, bytes)
+	if err != nil {
+		e.errorf(at, "failed to include %s: %v", name, err)
+		return nil
+	}
+	return e.addFile(name, root)
+}
+
+// addFile splits the components and params off from the root of a parsed
+// definition file.
+func (e *expander) addFile(name string, root *yaml.Node) *loadedFile {
+	setOrigin(root, name, e.origin)
+	untag(root)
+	e.order = append(e.order, name)
+
+	file := &loadedFile{
+		root: root,
+		scope: &scope{
+			file:       name,
+			components: make(map[string]*component),
+			imports:    make(map[string]string),
+		},
+	}
+	e.files[name] = file
+	if root.Kind != yaml.MappingNode {
+		// Reported by the validator
+		return file
+	}
+
+	if node := takeMappingValue(root, "params"); node != nil {
+		if node.Kind != yaml.MappingNode {
+			e.errorf(node, "params must be a mapping, got %s", nodeKindName(node))
+		} else {
+			file.params = node
+		}
+	}
+	if node := takeMappingValue(root, "imports"); node != nil {
+		e.addImports(file.scope, node)
+	}
+	if node := takeMappingValue(root, "components"); node != nil {
+		e.addComponents(file.scope, node)
+	}
+	return file
+}
+
+// addImports adds the namespace prefixes declared under the `imports` key of
+// a definition file. A type starting with a prefix and a dot is the type in
+// the namespace of the prefix, e.g. with
+//
+//	imports:
+//	  acme: github.com/acme/ui/widgets
+//
+// `type: acme.Gauge` is the element "github.com/acme/ui/widgets.Gauge".
+func (e *expander) addImports(sc *scope, node *yaml.Node) {
+	if node.Kind != yaml.MappingNode {
+		e.errorf(node, "imports must be a mapping, got %s", nodeKindName(node))
+		return
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		switch {
+		case key.Value == "" || strings.ContainsAny(key.Value, "./ "):
+			e.errorf(key, "invalid import prefix %q", key.Value)
+		case value.Kind != yaml.ScalarNode || value.Value == "":
+			e.errorf(value, "import %s must be a namespace", key.Value)
+		case sc.imports[key.Value] != "":
+			e.errorf(key, "duplicate import %s", key.Value)
+		default:
+			sc.imports[key.Value] = value.Value
+		}
+	}
+}
+
+// resolveType replaces the import prefix of the type of the element node by
+// its namespace (see addImports).
+func (e *expander) resolveType(node *yaml.Node, sc *scope) {
+	typeNode := mappingValue(node, "type")
+	if typeNode == nil || len(sc.imports) == 0 {
+		return
+	}
+	dot := strings.LastIndex(typeNode.Value, ".")
+	if dot < 0 {
+		return
+	}
+	if namespace, ok := sc.imports[typeNode.Value[:dot]]; ok {
+		typeNode.Value = namespace + typeNode.Value[dot:]
+	}
+}
+
+func (e *expander) addComponents(sc *scope, node *yaml.Node) {
+	if node.Kind != yaml.MappingNode {
+		e.errorf(node, "components must be a mapping, got %s", nodeKindName(node))
+		return
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if e.registry.Has(key.Value) {
+			e.errorf(key, "component %s has the name of an element", key.Value)
+			continue
+		}
+		if _, ok := sc.components[key.Value]; ok {
+			e.errorf(key, "duplicate component %s", key.Value)
+			continue
+		}
+		if value.Kind != yaml.MappingNode {
+			e.errorf(value, "component must be a mapping, got %s", nodeKindName(value))
+			continue
+		}
+
+		comp := &component{name: key.Value, scope: sc}
+		if inc := mappingValue(value, "include"); inc != nil {
+			file := e.loadFile(e.resolve(sc.file, inc.Value), inc)
+			if file == nil {
+				continue
+			}
+			comp.params, comp.body, comp.scope = file.params, file.root, file.scope
+		} else {
+			comp.params = mappingValue(value, "params")
+			comp.body = mappingValue(value, "element")
+			if comp.body == nil {
+				e.errorf(value, "component %s has no element", key.Value)
+				continue
+			}
+			if comp.params != nil && comp.params.Kind != yaml.MappingNode {
+				e.errorf(comp.params, "params must be a mapping, got %s",
+					nodeKindName(comp.params))
+				continue
+			}
+		}
+		sc.components[key.Value] = comp
+	}
+}
+
+// resolve returns the path of the included file name, relative to the
+// directory of the including file.
+func (e *expander) resolve(from string, name string) string {
+	return path.Join(path.Dir(from), name)
+}
+
+// expandElement expands the component instances and includes in the element
+// node and its children. It returns nil when the element could not be
+// expanded.
+func (e *expander) expandElement(node *yaml.Node, sc *scope) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		// Reported by the validator
+		return node
+	}
+
+	if inc := mappingValue(node, "include"); inc != nil {
+		file := e.loadFile(e.resolve(sc.file, inc.Value), inc)
+		if file == nil {
+			return nil
+		}
+		return e.instantiate(node, &component{
+			name:   file.scope.file,
+			params: file.params,
+			body:   file.root,
+			scope:  file.scope,
+		}, sc)
+	}
+
+	if typeNode := mappingValue(node, "type"); typeNode != nil {
+		if comp, ok := sc.components[typeNode.Value]; ok {
+			return e.instantiate(node, comp, sc)
+		}
+	}
+	e.resolveType(node, sc)
+
+	if children := mappingValue(node, "children"); children != nil &&
+		children.Kind == yaml.SequenceNode {
+		content := make([]*yaml.Node, 0, len(children.Content))
+		for _, child := range children.Content {
+			if child = e.expandElement(child, sc); child != nil {
+				content = append(content, child)
+			}
+		}
+		children.Content = content
+	}
+	for _, key := range []string{"child", "template"} {
+		if child := mappingValue(node, key); child != nil {
+			if expanded := e.expandElement(child, sc); expanded != nil {
+				setMappingValue(node, key, expanded)
+			}
+		}
+	}
+	return node
+}
+
+// instantiate returns the elements of the component comp for the instance
+// node, that is declared in the scope sc.
+func (e *expander) instantiate(node *yaml.Node, comp *component, sc *scope) *yaml.Node {
+	for _, name := range e.stack {
+		if name == comp.name {
+			e.errorf(node, "component %s includes itself", comp.name)
+			return nil
+		}
+	}
+
+	args := e.arguments(node, comp)
+
+	// The children of the instance belong to the scope of the instance
+	var slotContent []*yaml.Node
+	if children := mappingValue(node, "children"); children != nil {
+		if children.Kind != yaml.SequenceNode {
+			e.errorf(children, "children must be a list of elements")
+			return nil
+		}
+		for _, child := range children.Content {
+			if child = e.expandElement(child, sc); child != nil {
+				slotContent = append(slotContent, child)
+			}
+		}
+	}
+	if child := mappingValue(node, "child"); child != nil {
+		if child = e.expandElement(child, sc); child != nil {
+			slotContent = append(slotContent, child)
+		}
+	}
+
+	body := clone(comp.body, e.origin)
+	substitute(body, args, e.origin)
+
+	e.stack = append(e.stack, comp.name)
+	body = e.expandElement(body, comp.scope)
+	e.stack = e.stack[:len(e.stack)-1]
+	if body == nil || body.Kind != yaml.MappingNode {
+		return body
+	}
+
+	if id := mappingValue(node, "id"); id != nil {
+		prefixIds(body, id.Value+".")
+		setMappingValue(body, "id", clone(id, e.origin))
+	}
+	if weight := mappingValue(node, "weight"); weight != nil {
+		setMappingValue(body, "weight", clone(weight, e.origin))
+	}
+
+	if slots := e.fillSlots(body, slotContent); slots == 0 && len(slotContent) > 0 {
+		e.errorf(node, "component %s has no slot for children", comp.name)
+	}
+	return body
+}
+
+// arguments returns the values for the parameters of comp, from the
+// properties of the instance node or the defaults of the parameters.
+func (e *expander) arguments(node *yaml.Node, comp *component) map[string]*yaml.Node {
+	args := make(map[string]*yaml.Node)
+	if node != nil {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			switch key.Value {
+			case "type", "include", "id", "weight", "child", "children":
+				continue
+			}
+			if comp.params == nil || mappingValue(comp.params, key.Value) == nil {
+				e.errorf(key, "unknown parameter %q for component %s",
+					key.Value, comp.name)
+				continue
+			}
+			args[key.Value] = value
+		}
+	}
+
+	if comp.params == nil {
+		return args
+	}
+	for i := 0; i+1 < len(comp.params.Content); i += 2 {
+		key, value := comp.params.Content[i], comp.params.Content[i+1]
+		if _, ok := args[key.Value]; ok {
+			continue
+		}
+		if value.Tag == "!!null" {
+			at := node
+			if at == nil {
+				at = key
+			}
+			e.errorf(at, "missing parameter %q for component %s",
+				key.Value, comp.name)
+			continue
+		}
+		args[key.Value] = value
+	}
+	return args
+}
+
+// fillSlots replaces the slot elements in node by content and returns the
+// number of slots found.
+func (e *expander) fillSlots(node *yaml.Node, content []*yaml.Node) (slots int) {
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+
+	if children := mappingValue(node, "children"); children != nil &&
+		children.Kind == yaml.SequenceNode {
+		filled := make([]*yaml.Node, 0, len(children.Content))
+		for _, child := range children.Content {
+			if isSlot(child) {
+				slots++
+				filled = append(filled, content...)
+				continue
+			}
+			slots += e.fillSlots(child, content)
+			filled = append(filled, child)
+		}
+		children.Content = filled
+	}
+
+	if child := mappingValue(node, "child"); child != nil {
+		if !isSlot(child) {
+			return slots + e.fillSlots(child, content)
+		}
+		slots++
+		switch len(content) {
+		case 0:
+			takeMappingValue(node, "child")
+		case 1:
+			setMappingValue(node, "child", content[0])
+		default:
+			e.errorf(child, "slot accepts a single element, got %d", len(content))
+		}
+	}
+	return
+}
+
+// checkIds reports ids that are used by more than one element.
+func (e *expander) checkIds(root *yaml.Node) {
+	seen := make(map[string]*yaml.Node)
+	walkElements(root, func(node *yaml.Node) {
+		id := mappingValue(node, "id")
+		if id == nil {
+			return
+		}
+		if first, ok := seen[id.Value]; ok {
+			e.errorf(id, "duplicate id %q, first used at %s:%d", id.Value,
+				e.origin[first], first.Line)
+			return
+		}
+		seen[id.Value] = id
+	})
+}
+
+func isSlot(node *yaml.Node) bool {
+	typeNode := mappingValue(node, "type")
+	return typeNode != nil && typeNode.Value == slotType
+}
+
+// walkElements calls fn for node and all of its child elements.
+func walkElements(node *yaml.Node, fn func(*yaml.Node)) {
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+	fn(node)
+	if children := mappingValue(node, "children"); children != nil &&
+		children.Kind == yaml.SequenceNode {
+		for _, child := range children.Content {
+			walkElements(child, fn)
+		}
+	}
+	if child := mappingValue(node, "child"); child != nil {
+		walkElements(child, fn)
+	}
+}
+
+// prefixIds prefixes the ids of node and its child elements.
+func prefixIds(node *yaml.Node, prefix string) {
+	walkElements(node, func(elem *yaml.Node) {
+		if id := mappingValue(elem, "id"); id != nil {
+			id.Value = prefix + id.Value
+		}
+	})
+}
+
+// substitute replaces the `$name` values in node by the argument for the
+// parameter name.
+func substitute(node *yaml.Node, args map[string]*yaml.Node, origin map[*yaml.Node]string) {
+	for i, child := range node.Content {
+		if node.Kind == yaml.MappingNode && i%2 == 0 {
+			continue
+		}
+		if child.Kind == yaml.ScalarNode && strings.HasPrefix(child.Value, "$") {
+			if arg, ok := args[child.Value[1:]]; ok {
+				node.Content[i] = clone(arg, origin)
+			}
+			continue
+		}
+		substitute(child, args, origin)
+	}
+}
+
+// clone returns a deep copy of node, that was read from the same file.
+func clone(node *yaml.Node, origin map[*yaml.Node]string) *yaml.Node {
+	cpy := *node
+	cpy.Content = make([]*yaml.Node, len(node.Content))
+	for i, child := range node.Content {
+		cpy.Content[i] = clone(child, origin)
+	}
+	origin[&cpy] = origin[node]
+	return &cpy
+}
+
+// untag turns the local tags without a value back into strings. YAML reads
+// an unquoted `!Boiling` as a tag, but in a definition it is an inverted
+// binding reference.
+func untag(node *yaml.Node) {
+	if node.Kind == yaml.ScalarNode && node.Value == "" &&
+		strings.HasPrefix(node.Tag, "!") && !strings.HasPrefix(node.Tag, "!!") {
+		node.Value = node.Tag
+		node.Tag = "!!str"
+	}
+	for _, child := range node.Content {
+		untag(child)
+	}
+}
+
+func setOrigin(node *yaml.Node, file string, origin map[*yaml.Node]string) {
+	origin[node] = file
+	for _, child := range node.Content {
+		setOrigin(child, file, origin)
+	}
+}
+
+// setMappingValue sets the value of key in a mapping node, adding the key if
+// it is missing.
+func setMappingValue(node *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content[i+1] = value
+			return
+		}
+	}
+	node.Content = append(node.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key,
+			Line: value.Line, Column: value.Column}, value)
+}
+
+// takeMappingValue removes key from a mapping node and returns its value.
+func takeMappingValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			value := node.Content[i+1]
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+			return value
+		}
+	}
+	return nil
+}
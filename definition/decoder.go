@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: MIT
+
+package definition
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Decoder decodes a definition file into a tree of YAML nodes. Definitions in
+// every format are expanded, validated and created from this tree, so they
+// all support the same properties, components and includes.
+//
+// Decoders are selected by the extension of the definition file, see
+// RegisterDecoder. Nodes should carry the line and column of the value they
+// were decoded from, which are used in the errors of the definition. Decoders
+// that can not tell leave them 0.
+type Decoder interface {
+	Decode(data []byte) (*yaml.Node, error)
+}
+
+// DecoderFunc is a function implementing Decoder.
+type DecoderFunc func(data []byte) (*yaml.Node, error)
+
+func (f DecoderFunc) Decode(data []byte) (*yaml.Node, error) {
+	return f(data)
+}
+
+var (
+	decodersMu sync.RWMutex
+	decoders   = map[string]Decoder{
+		".yml":  DecoderFunc(decodeYAML),
+		".yaml": DecoderFunc(decodeYAML),
+		".json": DecoderFunc(decodeJSON),
+		".toml": DecoderFunc(decodeTOML),
+	}
+)
+
+// RegisterDecoder registers the decoder for definition files with the
+// extension ext (e.g. ".json"), replacing the decoder registered before.
+// Files with an unknown extension are decoded as YAML.
+func RegisterDecoder(ext string, decoder Decoder) {
+	decodersMu.Lock()
+	defer decodersMu.Unlock()
+	decoders[strings.ToLower(ext)] = decoder
+}
+
+// decoderFor returns the decoder for the definition file name.
+func decoderFor(name string) Decoder {
+	decodersMu.RLock()
+	defer decodersMu.RUnlock()
+	if d, ok := decoders[strings.ToLower(path.Ext(name))]; ok {
+		return d
+	}
+	return DecoderFunc(decodeYAML)
+}
+
+// decode decodes the definition file name with the decoder for its
+// extension.
+func decode(name string, data []byte) (*yaml.Node, error) {
+	root, err := decoderFor(name).Decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("definition has syntax error: %w", err)
+	}
+	if root == nil {
+		return nil, fmt.Errorf("definition is empty")
+	}
+	return root, nil
+}
+
+func decodeYAML(data []byte) (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+	return doc.Content[0], nil
+}
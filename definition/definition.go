@@ -3,18 +3,20 @@
 package definition
 
 import (
-	"embed"
 	"fmt"
 	"io"
 	"io/fs"
+	"maps"
 
 	"github.com/mheremans/goui/types"
 	"gopkg.in/yaml.v3"
 )
 
 type Definition struct {
-	root  DefinitionType
-	index map[string]DefinitionType
+	root     DefinitionType
+	index    map[string]DefinitionType
+	elements []DefinitionType
+	files    []string
 }
 
 func (d Definition) Root() types.UIElement {
@@ -26,6 +28,52 @@ func (d Definition) ElementById(id string) (elem types.UIElement, ok bool) {
 	return
 }
 
+// Files returns the names of the definition file and all files it includes.
+func (d Definition) Files() []string {
+	return d.files
+}
+
+// Elements returns the ids and elements of all elements that have an id.
+func (d Definition) Elements() map[string]types.UIElement {
+	res := make(map[string]types.UIElement, len(d.index))
+	for id, elem := range d.index {
+		res[id] = elem
+	}
+	return res
+}
+
+// TransferState transfers the state of the elements of old to the elements
+// of d with the same id and type (see types.StatefulElement).
+func (d *Definition) TransferState(old *Definition) {
+	if old == nil {
+		return
+	}
+	for id, elem := range d.index {
+		stateful, ok := elem.(types.StatefulElement)
+		if !ok {
+			continue
+		}
+		if oldElem, ok := old.index[id]; ok {
+			stateful.TransferState(oldElem)
+		}
+	}
+}
+
+// Dispose disposes all elements of the definition (see types.Disposable) and
+// unbinds their conditions. The definition must not be used afterwards.
+func (d *Definition) Dispose() {
+	for _, elem := range d.elements {
+		if disposable, ok := elem.(types.Disposable); ok {
+			disposable.Dispose()
+		}
+		if conditional, ok := elem.(types.Conditional); ok {
+			conditional.BindVisible(nil)
+			conditional.BindEnabled(nil)
+		}
+	}
+	d.elements = nil
+}
+
 func ElementById[T any](def *Definition, id string) (elem T, ok bool) {
 	tmp, ok := def.index[id]
 	if !ok {
@@ -35,10 +83,52 @@ func ElementById[T any](def *Definition, id string) (elem T, ok bool) {
 	return
 }
 
+// Options configures how a definition is loaded.
+type Options struct {
+	// Strict rejects properties that are not part of the schema of an
+	// element.
+	Strict bool
+
+	// Registry holds the elements that can be used in the definition, the
+	// default registry (see RegisterUIElement) when nil.
+	Registry *Registry
+}
+
+// New loads the definition name from filesystem and creates its elements.
+//
+// The definition is decoded according to the extension of name: YAML (the
+// default), JSON (.json) or TOML (.toml), see Decoder.
+//
+// Components and included definitions are expanded first, see component for
+// the syntax. Included files are looked up in filesystem, relative to the
+// including file. The ids of elements within a component instance are
+// prefixed with the id of the instance, e.g. "card.title".
+//
+// The definition is validated against the schemas of the registered
+// elements first. All problems found are returned together as Errors, with
+// the line and column of each problem.
+//
+// Translated messages (see IsTranslation) are looked up with the localizer of
+// the window of ctx (see Localized) and follow its locale.
+//
+// The elements are looked up by their type in the default registry (see
+// Registry). A definition file may declare namespace prefixes for the types
+// under the `imports` key, e.g. `acme: github.com/acme/ui/widgets` for
+// `type: acme.Gauge`.
 func New(
 	ctx types.Context,
-	filesystem embed.FS,
+	filesystem fs.FS,
+	name string,
+) (def *Definition, err error) {
+	return NewWithOptions(ctx, filesystem, name, Options{})
+}
+
+// NewWithOptions is like New, with additional options.
+func NewWithOptions(
+	ctx types.Context,
+	filesystem fs.FS,
 	name string,
+	options Options,
 ) (def *Definition, err error) {
 	var bytes []byte
 	var fh fs.File
@@ -54,20 +144,49 @@ func New(
 		return
 	}
 
+	root, err := decode(name, bytes)
+	if err != nil {
+		return
+	}
+	return build(ctx, filesystem, name, root, options)
+}
+
+// build expands, validates and creates the definition with the root node, of
+// the file name in filesystem.
+func build(
+	ctx types.Context,
+	filesystem fs.FS,
+	name string,
+	root *yaml.Node,
+	options Options,
+) (def *Definition, err error) {
+	doc, err := parse(ctx, filesystem, name, root, options)
+	if err != nil {
+		return
+	}
+
 	defMap := make(map[string]interface{})
-	if err = yaml.Unmarshal(bytes, defMap); err != nil {
+	if err = doc.root.Decode(defMap); err != nil {
 		err = fmt.Errorf("definition has syntax error: %w", err)
 		return
 	}
 
-	def, err = createLayout(ctx, defMap)
+	def, err = createLayout(withRegistry(ctx, doc.registry), defMap)
 	if err != nil {
 		err = fmt.Errorf("failed to create definition: %w", err)
 		return
 	}
+	def.files = doc.files
 	return
 }
 
+// NewFromMap creates the elements of a definition that was already decoded,
+// e.g. the item template of a list. The definition is not validated. The
+// elements are created from the registry of the definition ctx belongs to.
+func NewFromMap(ctx types.Context, data map[string]any) (*Definition, error) {
+	return createLayout(ctx, data)
+}
+
 func createLayout(
 	ctx types.Context,
 	defMap map[string]any,
@@ -84,6 +203,7 @@ func createLayout(
 		err = fmt.Errorf("failed to create layout: %w", err)
 		return
 	}
+	def.elements = append(def.elements, root)
 
 	for _, childDef := range childDefs {
 		var child DefinitionType
@@ -129,6 +249,7 @@ func createChild(
 		err = fmt.Errorf("failed to create child: %w", err)
 		return
 	}
+	def.elements = append(def.elements, elem)
 
 	for _, childDef := range childDefs {
 		var child DefinitionType
@@ -158,6 +279,35 @@ func createChild(
 	return
 }
 
+// moveExpressions moves the binding expressions and translated messages of
+// properties that bind to a binding property (see Property.BindsTo) to that
+// property. It returns a copy of defMap, so the definition it was read from
+// (e.g. the template of a list) keeps the properties as written.
+func moveExpressions(schema []Property, defMap map[string]any) map[string]any {
+	defMap = maps.Clone(defMap)
+	for _, prop := range schema {
+		if prop.Binding == "" {
+			continue
+		}
+		value := defMap[prop.Name]
+		s, isString := value.(string)
+		switch {
+		case IsTranslation(value), isString && IsExpression(s):
+		case isString:
+			defMap[prop.Name] = unescapeTranslation(s)
+			continue
+		default:
+			continue
+		}
+		if _, ok := defMap[prop.Binding]; ok {
+			continue
+		}
+		defMap[prop.Binding] = value
+		delete(defMap, prop.Name)
+	}
+	return defMap
+}
+
 func createElement(
 	ctx types.Context,
 	defMap map[string]interface{},
@@ -179,20 +329,49 @@ func createElement(
 		weight = &w
 	}
 
-	elem, err = Instantiate(ctx, typeName, defMap)
+	registry := registryOf(ctx)
+	schema, _ := registry.Schema(typeName)
+	defMap = moveExpressions(schema, defMap)
+	if err = checkExpressions(ctx, schema, defMap); err != nil {
+		err = fmt.Errorf("%s: %w", typeName, err)
+		return
+	}
+	elem, err = registry.Instantiate(ctx, typeName, defMap)
 	if err != nil {
 		return
 	}
+	if conditional, ok := elem.(types.Conditional); ok {
+		if visible, ok := BoolBindingFromMap(ctx, defMap, "visible"); ok {
+			conditional.BindVisible(visible)
+		}
+		if enabled, ok := BoolBindingFromMap(ctx, defMap, "enabled"); ok {
+			conditional.BindEnabled(enabled)
+		}
+	}
 
 	if children, ok := defMap["children"]; ok {
-		childDefinitions = make([]map[string]any, 0)
-		for _, chld := range children.([]any) {
-			childDefinitions = append(
-				childDefinitions, chld.(map[string]any))
+		list, ok := children.([]any)
+		if !ok {
+			err = fmt.Errorf("%s: children must be a list of elements", typeName)
+			return
+		}
+		childDefinitions = make([]map[string]any, 0, len(list))
+		for _, chld := range list {
+			chldMap, ok := chld.(map[string]any)
+			if !ok {
+				err = fmt.Errorf("%s: child must be an element", typeName)
+				return
+			}
+			childDefinitions = append(childDefinitions, chldMap)
 		}
 	}
 	if child, ok := defMap["child"]; ok {
-		childDefinitions = []map[string]any{child.(map[string]any)}
+		chldMap, ok := child.(map[string]any)
+		if !ok {
+			err = fmt.Errorf("%s: child must be an element", typeName)
+			return
+		}
+		childDefinitions = []map[string]any{chldMap}
 	}
 	return
 }
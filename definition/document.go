@@ -0,0 +1,310 @@
+// SPDX-License-Identifier: MIT
+
+package definition
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/mheremans/goui/types"
+	"gopkg.in/yaml.v3"
+)
+
+// Document is a definition that was expanded and validated, without creating
+// its elements (see Parse). It is used by tools, e.g. to generate code for a
+// definition or to check it without opening a window.
+type Document struct {
+	root     *yaml.Node
+	files    []string
+	origin   map[*yaml.Node]string // File each node was read from
+	registry *Registry
+}
+
+// ElementInfo describes an element of a Document.
+type ElementInfo struct {
+	Type     string
+	ID       string       // Empty for elements without an id
+	GoType   reflect.Type // Pointer type of the element
+	Template bool         // Part of an item template, created for every item
+	File     string
+	Node     *yaml.Node
+}
+
+// ReferenceKind is the kind of a Reference.
+type ReferenceKind uint8
+
+const (
+	FunctionReference ReferenceKind = iota // Function exported by the view
+	BindingReference                       // Binding of the view model
+)
+
+func (k ReferenceKind) String() string {
+	return [...]string{"function", "binding"}[k]
+}
+
+// Reference is a reference from a Document to a function exported by the
+// view, or to a binding of the view model.
+type Reference struct {
+	Kind     ReferenceKind
+	Name     string       // Name of the function or binding
+	Property Property     // Property the reference is the value of
+	Type     reflect.Type // Expected type, nil when any type is accepted
+	Element  *ElementInfo
+	File     string
+	Node     *yaml.Node
+}
+
+// Parse loads the definition name from filesystem, expands its components
+// and includes and validates it, like New, without creating its elements.
+//
+// ctx may be nil, the references to functions, bindings and translated
+// messages are then not checked.
+func Parse(
+	ctx types.Context,
+	filesystem fs.FS,
+	name string,
+	options Options,
+) (doc *Document, err error) {
+	var bytes []byte
+	var fh fs.File
+
+	if fh, err = filesystem.Open(name); err != nil {
+		err = fmt.Errorf("no definition found: %w", err)
+		return
+	}
+	defer fh.Close()
+
+	if bytes, err = io.ReadAll(fh); err != nil {
+		err = fmt.Errorf("unable to read definition: %w", err)
+		return
+	}
+
+	root, err := decode(name, bytes)
+	if err != nil {
+		return
+	}
+	return parse(ctx, filesystem, name, root, options)
+}
+
+// Files returns the names of the definition file name and the files it
+// includes, as far as they can be resolved. Unlike Document.Files, it also
+// returns them when the definition has errors, including the included files
+// that failed to load, e.g. to watch them for a fix.
+func Files(filesystem fs.FS, name string, options Options) []string {
+	files := []string{name}
+	bytes, err := fs.ReadFile(filesystem, name)
+	if err != nil {
+		return files
+	}
+	root, err := decode(name, bytes)
+	if err != nil {
+		return files
+	}
+
+	registry := options.Registry
+	if registry == nil {
+		registry = defaultRegistry
+	}
+	e := newExpander(filesystem, registry)
+	e.expandRoot(name, root)
+	for _, file := range e.tried {
+		if !slices.Contains(files, file) {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// parse expands and validates the root node of the file name in filesystem.
+func parse(
+	ctx types.Context,
+	filesystem fs.FS,
+	name string,
+	root *yaml.Node,
+	options Options,
+) (doc *Document, err error) {
+	registry := options.Registry
+	if registry == nil {
+		registry = registryOf(ctx)
+	}
+
+	e := newExpander(filesystem, registry)
+	root = e.expandRoot(name, root)
+	if len(e.errs) > 0 {
+		err = e.errs
+		return
+	}
+
+	v := &validator{
+		ctx:      ctx,
+		registry: registry,
+		file:     name,
+		strict:   options.Strict,
+		origin:   e.origin,
+	}
+	v.validateElement(root)
+	if len(v.errs) > 0 {
+		err = v.errs
+		return
+	}
+
+	doc = &Document{
+		root:     root,
+		files:    e.order,
+		origin:   e.origin,
+		registry: registry,
+	}
+	return
+}
+
+// Root returns the root element node of the expanded definition.
+func (d *Document) Root() *yaml.Node {
+	return d.root
+}
+
+// Files returns the names of the definition file and all files it includes.
+func (d *Document) Files() []string {
+	return d.files
+}
+
+// Registry returns the registry the elements of the document are from.
+func (d *Document) Registry() *Registry {
+	return d.registry
+}
+
+// Elements returns the elements of the document, in the order they appear.
+func (d *Document) Elements() []*ElementInfo {
+	var elements []*ElementInfo
+	d.walk(func(info *ElementInfo, _ []Property) {
+		elements = append(elements, info)
+	})
+	return elements
+}
+
+// References returns the references to functions and bindings of the
+// document, in the order they appear. Fields of the item of a template (e.g.
+// `.Name`) are not included.
+func (d *Document) References() []Reference {
+	var refs []Reference
+	add := func(info *ElementInfo, kind ReferenceKind, prop Property, node *yaml.Node, name string) {
+		if strings.HasPrefix(name, ".") {
+			return
+		}
+		typ := prop.Type
+		if kind == BindingReference {
+			if strings.HasPrefix(name, "!") {
+				// Inverted boolean binding (see BoolBindingFromMap)
+				name = name[1:]
+				typ = reflect.TypeFor[types.ValueBinding[bool]]()
+			}
+			name, _, _ = splitBindingRef(name)
+		}
+		refs = append(refs, Reference{
+			Kind:     kind,
+			Name:     name,
+			Property: prop,
+			Type:     typ,
+			Element:  info,
+			File:     d.origin[node],
+			Node:     node,
+		})
+	}
+
+	d.walk(func(info *ElementInfo, schema []Property) {
+		node := info.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			prop, ok := findProperty(schema, key.Value)
+			if !ok {
+				continue
+			}
+			binding := prop
+			if prop.Binding != "" {
+				binding, _ = findProperty(schema, prop.Binding)
+			}
+
+			switch {
+			case prop.Kind == KindChild || prop.Kind == KindChildren:
+			case isTranslationNode(value) && (prop.Binding != "" || prop.Kind == KindBinding):
+				// Only the string arguments reference bindings
+				if args := mappingValue(value, "args"); args != nil {
+					for j := 1; j < len(args.Content); j += 2 {
+						if arg := args.Content[j]; arg.ShortTag() == "!!str" {
+							add(info, BindingReference, Property{Name: prop.Name, Kind: KindBinding},
+								arg, arg.Value)
+						}
+					}
+				}
+			case value.Kind != yaml.ScalarNode:
+			case (prop.Binding != "" || prop.Kind == KindBinding) && IsExpression(value.Value):
+				t, err := parseTemplate(value.Value)
+				if err != nil {
+					continue
+				}
+				for _, ref := range t.refs {
+					add(info, BindingReference, Property{Name: prop.Name, Kind: KindBinding},
+						value, ref)
+				}
+			case prop.Kind == KindFunction:
+				add(info, FunctionReference, prop, value, value.Value)
+			case prop.Kind == KindBinding:
+				// A converter adapts the binding, so its type is not known
+				if mappingValue(node, "converter") != nil {
+					binding.Type = nil
+				}
+				add(info, BindingReference, binding, value, value.Value)
+			}
+		}
+	})
+	return refs
+}
+
+// walk calls fn for every element of the document, with the schema of the
+// element.
+func (d *Document) walk(fn func(info *ElementInfo, schema []Property)) {
+	var visit func(node *yaml.Node, template bool)
+	visit = func(node *yaml.Node, template bool) {
+		if node == nil || node.Kind != yaml.MappingNode {
+			return
+		}
+		info := &ElementInfo{
+			Template: template,
+			File:     d.origin[node],
+			Node:     node,
+		}
+		if typeNode := mappingValue(node, "type"); typeNode != nil {
+			info.Type = typeNode.Value
+		}
+		if id := mappingValue(node, "id"); id != nil {
+			info.ID = id.Value
+		}
+		if entry, ok := d.registry.lookup(info.Type); ok {
+			info.GoType = reflect.PointerTo(entry.typ)
+		}
+		schema, _ := d.registry.Schema(info.Type)
+		fn(info, schema)
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			switch key.Value {
+			case "child":
+				visit(value, template)
+			case "children":
+				for _, child := range value.Content {
+					visit(child, template)
+				}
+			default:
+				// Other elements, e.g. the item template of a list, are
+				// created later
+				if prop, ok := findProperty(schema, key.Value); ok && prop.Kind == KindChild {
+					visit(value, true)
+				}
+			}
+		}
+	}
+	visit(d.root, false)
+}
@@ -0,0 +1,723 @@
+// SPDX-License-Identifier: MIT
+
+package definition
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/mheremans/goui/types"
+)
+
+// Binding expressions
+//
+// A binding property (or a property bound to one, see Property.BindsTo) can
+// hold an expression instead of a binding reference:
+//
+//	text: "{Time Remaining} min left"
+//	value: "{Progress * 100}"
+//	visible: "{Count > 0 && !Boiling}"
+//	text: "{Progress * 100:%.0f}%"
+//
+// Every part between braces is an expression, with an optional fmt format
+// after the first colon; integral numbers can be formatted with the integer
+// verbs, e.g. `{Count:%d}`. The rest is literal text; use `{{` and `}}` for literal
+// braces. A value consisting of a single expression has the type of the
+// expression, otherwise the parts are formatted and joined to a string.
+//
+// Expressions support numbers, 'strings', true and false, binding references,
+// parentheses, the unary operators ! and -, and the binary operators
+// * / % + - < <= > >= == != && || (with the usual precedence). A + with a
+// string operand joins strings. Binding references are names of bindings;
+// names with spaces or operator characters are quoted with backticks, e.g.
+// `Time Remaining`. Words within a name may also be separated by spaces
+// without quotes, like in the first example above.
+//
+// Expressions are parsed once, when the definition is loaded, into a
+// types.ComputedBinding that depends on the referenced bindings.
+
+// IsExpression returns whether the value of a property is a binding
+// expression, i.e. whether it contains an unescaped `{`.
+func IsExpression(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] != '{' {
+			continue
+		}
+		if i+1 < len(s) && s[i+1] == '{' {
+			i++
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// template is a parsed binding expression.
+type template struct {
+	source string
+	parts  []templatePart
+	refs   []string // Binding references, in order of appearance
+}
+
+type templatePart struct {
+	text   string
+	expr   expr // nil for literal text
+	format string
+}
+
+// ExpressionError is an error in a binding expression, at byte offset Offset.
+type ExpressionError struct {
+	Offset  int
+	Message string
+}
+
+func (e *ExpressionError) Error() string {
+	return fmt.Sprintf("expression error at offset %d: %s", e.Offset, e.Message)
+}
+
+func parseTemplate(s string) (*template, error) {
+	t := &template{source: s}
+	var text strings.Builder
+
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '{' && i+1 < len(s) && s[i+1] == '{':
+			text.WriteByte('{')
+			i += 2
+		case c == '}' && i+1 < len(s) && s[i+1] == '}':
+			text.WriteByte('}')
+			i += 2
+		case c == '}':
+			return nil, &ExpressionError{Offset: i, Message: "unexpected }"}
+		case c == '{':
+			end := closingBrace(s, i+1)
+			if end < 0 {
+				return nil, &ExpressionError{Offset: i, Message: "missing }"}
+			}
+			if text.Len() > 0 {
+				t.parts = append(t.parts, templatePart{text: text.String()})
+				text.Reset()
+			}
+
+			body, format := splitFormat(s[i+1 : end])
+			p := &parser{src: body, base: i + 1}
+			e, err := p.parse()
+			if err != nil {
+				return nil, err
+			}
+			t.refs = append(t.refs, p.refs...)
+			t.parts = append(t.parts, templatePart{expr: e, format: format})
+			i = end + 1
+		default:
+			text.WriteByte(c)
+			i++
+		}
+	}
+	if text.Len() > 0 {
+		t.parts = append(t.parts, templatePart{text: text.String()})
+	}
+	return t, nil
+}
+
+// closingBrace returns the index of the } closing an expression starting at
+// start, skipping quoted strings and names.
+func closingBrace(s string, start int) int {
+	var quote byte
+	for i := start; i < len(s); i++ {
+		switch {
+		case quote != 0:
+			if s[i] == quote {
+				quote = 0
+			}
+		case s[i] == '\'' || s[i] == '`':
+			quote = s[i]
+		case s[i] == '}':
+			return i
+		}
+	}
+	return -1
+}
+
+// splitFormat splits the format off from an expression at the first colon
+// outside quotes, parentheses and brackets, so the format itself may contain
+// colons.
+func splitFormat(s string) (body string, format string) {
+	var quote byte
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch {
+		case quote != 0:
+			if s[i] == quote {
+				quote = 0
+			}
+		case s[i] == '\'' || s[i] == '`':
+			quote = s[i]
+		case s[i] == '(' || s[i] == '[':
+			depth++
+		case s[i] == ')' || s[i] == ']':
+			depth--
+		case s[i] == ':' && depth == 0:
+			return s[:i], strings.TrimSpace(s[i+1:])
+		}
+	}
+	return s, ""
+}
+
+// formatArg returns the value of an expression as the argument of format.
+// Numbers are evaluated as float64, so integral numbers are passed as int
+// for the integer verbs, e.g. `{Count:%d}`.
+func formatArg(format string, v any) any {
+	n, ok := v.(float64)
+	if !ok || n != math.Trunc(n) || math.IsInf(n, 0) {
+		return v
+	}
+	if strings.ContainsRune("bcdoOxXU", formatVerb(format)) {
+		return int(n)
+	}
+	return v
+}
+
+// formatVerb returns the verb of the first directive of format, 0 if there
+// is none.
+func formatVerb(format string) rune {
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		if i+1 < len(format) && format[i+1] == '%' {
+			i++
+			continue
+		}
+		// Skip the flags, width and precision
+		j := i + 1
+		for j < len(format) && strings.IndexByte("+-# 0123456789.*[]", format[j]) >= 0 {
+			j++
+		}
+		if j < len(format) {
+			return rune(format[j])
+		}
+		return 0
+	}
+	return 0
+}
+
+// eval evaluates the template with the values of the referenced bindings.
+func (t *template) eval(env map[string]types.Bindable) any {
+	if len(t.parts) == 1 && t.parts[0].expr != nil && t.parts[0].format == "" {
+		return t.parts[0].expr.eval(env)
+	}
+
+	var res strings.Builder
+	for _, part := range t.parts {
+		if part.expr == nil {
+			res.WriteString(part.text)
+			continue
+		}
+		value := part.expr.eval(env)
+		if part.format != "" {
+			res.WriteString(fmt.Sprintf(part.format, formatArg(part.format, value)))
+		} else {
+			res.WriteString(formatValue(value))
+		}
+	}
+	return res.String()
+}
+
+// expr is a node of a parsed expression.
+type expr interface {
+	eval(env map[string]types.Bindable) any
+}
+
+type literal struct {
+	value any
+}
+
+func (e literal) eval(map[string]types.Bindable) any {
+	return e.value
+}
+
+type reference struct {
+	name string
+}
+
+func (e reference) eval(env map[string]types.Bindable) any {
+	return bindingValue(env[e.name])
+}
+
+type unary struct {
+	op string
+	x  expr
+}
+
+func (e unary) eval(env map[string]types.Bindable) any {
+	x := e.x.eval(env)
+	switch e.op {
+	case "!":
+		return !truthy(x)
+	case "-":
+		if n, ok := x.(float64); ok {
+			return -n
+		}
+	}
+	return nil
+}
+
+type binary struct {
+	op   string
+	l, r expr
+}
+
+func (e binary) eval(env map[string]types.Bindable) any {
+	// Short-circuit the logical operators
+	switch e.op {
+	case "&&":
+		return truthy(e.l.eval(env)) && truthy(e.r.eval(env))
+	case "||":
+		return truthy(e.l.eval(env)) || truthy(e.r.eval(env))
+	}
+
+	l, r := e.l.eval(env), e.r.eval(env)
+	switch e.op {
+	case "==":
+		return reflect.DeepEqual(l, r)
+	case "!=":
+		return !reflect.DeepEqual(l, r)
+	}
+
+	ls, lstr := l.(string)
+	rs, rstr := r.(string)
+	if lstr || rstr {
+		switch e.op {
+		case "+":
+			return formatValue(l) + formatValue(r)
+		case "<":
+			return lstr && rstr && ls < rs
+		case "<=":
+			return lstr && rstr && ls <= rs
+		case ">":
+			return lstr && rstr && ls > rs
+		case ">=":
+			return lstr && rstr && ls >= rs
+		}
+		return nil
+	}
+
+	ln, lok := l.(float64)
+	rn, rok := r.(float64)
+	if !lok || !rok {
+		return nil
+	}
+	switch e.op {
+	case "+":
+		return ln + rn
+	case "-":
+		return ln - rn
+	case "*":
+		return ln * rn
+	case "/":
+		return ln / rn
+	case "%":
+		return math.Mod(ln, rn)
+	case "<":
+		return ln < rn
+	case "<=":
+		return ln <= rn
+	case ">":
+		return ln > rn
+	case ">=":
+		return ln >= rn
+	}
+	return nil
+}
+
+// parser is a recursive descent parser for expressions.
+type parser struct {
+	src  string
+	pos  int
+	base int // Offset of src in the property value, for errors
+	refs []string
+}
+
+// binaryLevels are the binary operators, from the lowest to the highest
+// precedence.
+var binaryLevels = [][]string{
+	{"||"},
+	{"&&"},
+	{"==", "!="},
+	{"<=", ">=", "<", ">"},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return &ExpressionError{Offset: p.base + p.pos, Message: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) parse() (expr, error) {
+	p.skipSpace()
+	if p.pos == len(p.src) {
+		return nil, p.errorf("empty expression")
+	}
+	e, err := p.parseBinary(0)
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos < len(p.src) {
+		return nil, p.errorf("unexpected %q", p.src[p.pos:])
+	}
+	return e, nil
+}
+
+func (p *parser) parseBinary(level int) (expr, error) {
+	if level == len(binaryLevels) {
+		return p.parseUnary()
+	}
+	l, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.operator(binaryLevels[level])
+		if !ok {
+			return l, nil
+		}
+		r, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		l = binary{op: op, l: l, r: r}
+	}
+}
+
+func (p *parser) parseUnary() (expr, error) {
+	if op, ok := p.operator([]string{"!", "-"}); ok {
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return unary{op: op, x: x}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (expr, error) {
+	p.skipSpace()
+	if p.pos == len(p.src) {
+		return nil, p.errorf("missing operand")
+	}
+
+	c := p.src[p.pos]
+	switch {
+	case c == '(':
+		p.pos++
+		e, err := p.parseBinary(0)
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if p.pos == len(p.src) || p.src[p.pos] != ')' {
+			return nil, p.errorf("missing )")
+		}
+		p.pos++
+		return e, nil
+	case c == '\'':
+		end := strings.IndexByte(p.src[p.pos+1:], '\'')
+		if end < 0 {
+			return nil, p.errorf("unterminated string")
+		}
+		s := p.src[p.pos+1 : p.pos+1+end]
+		p.pos += end + 2
+		return literal{value: s}, nil
+	case c == '`':
+		end := strings.IndexByte(p.src[p.pos+1:], '`')
+		if end < 0 {
+			return nil, p.errorf("unterminated name")
+		}
+		name := p.src[p.pos+1 : p.pos+1+end]
+		p.pos += end + 2
+		return p.reference(name), nil
+	case c >= '0' && c <= '9':
+		start := p.pos
+		for p.pos < len(p.src) && (isDigit(p.src[p.pos]) || p.src[p.pos] == '.') {
+			p.pos++
+		}
+		n, err := strconv.ParseFloat(p.src[start:p.pos], 64)
+		if err != nil {
+			p.pos = start
+			return nil, p.errorf("invalid number")
+		}
+		return literal{value: n}, nil
+	case isNameStart(rune(c)):
+		name := p.name()
+		switch name {
+		case "true":
+			return literal{value: true}, nil
+		case "false":
+			return literal{value: false}, nil
+		}
+		return p.reference(name), nil
+	}
+	return nil, p.errorf("unexpected %q", string(c))
+}
+
+// name reads a binding reference: words separated by single spaces,
+// optionally followed by a [key] (see findBinding).
+func (p *parser) name() string {
+	start := p.pos
+	end := p.pos
+	for p.pos < len(p.src) {
+		r := rune(p.src[p.pos])
+		switch {
+		case isNameChar(r):
+			p.pos++
+			end = p.pos
+		case r == '[':
+			close := strings.IndexByte(p.src[p.pos:], ']')
+			if close < 0 {
+				return p.src[start:end]
+			}
+			p.pos += close + 1
+			end = p.pos
+		case r == ' ' && p.pos+1 < len(p.src) && isNameStart(rune(p.src[p.pos+1])) &&
+			!p.keywordAt(p.pos+1):
+			p.pos++
+		default:
+			p.pos = end
+			return p.src[start:end]
+		}
+	}
+	p.pos = end
+	return p.src[start:end]
+}
+
+// keywordAt returns whether the word at i is true or false, which ends a
+// name with spaces.
+func (p *parser) keywordAt(i int) bool {
+	for _, kw := range []string{"true", "false"} {
+		if strings.HasPrefix(p.src[i:], kw) &&
+			(i+len(kw) == len(p.src) || !isNameChar(rune(p.src[i+len(kw)]))) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) reference(name string) expr {
+	p.refs = append(p.refs, name)
+	return reference{name: name}
+}
+
+// operator consumes one of the operators ops, if it is next in the input.
+// Longer operators must come before their prefixes in ops.
+func (p *parser) operator(ops []string) (string, bool) {
+	p.skipSpace()
+	for _, op := range ops {
+		if !strings.HasPrefix(p.src[p.pos:], op) {
+			continue
+		}
+		// Do not take the ! of != or the < of <=
+		next := p.pos + len(op)
+		if (op == "!" || op == "<" || op == ">") && next < len(p.src) && p.src[next] == '=' {
+			continue
+		}
+		p.pos = next
+		return op, true
+	}
+	return "", false
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.src) && p.src[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isNameStart(r rune) bool {
+	return r == '_' || r == '.' || unicode.IsLetter(r)
+}
+
+func isNameChar(r rune) bool {
+	return isNameStart(r) || unicode.IsDigit(r)
+}
+
+// bindingValue returns the value of a binding as float64 (for all numbers),
+// string, bool or the value itself for other types.
+func bindingValue(b types.Bindable) any {
+	value, ok := types.BindingValue(b)
+	if !ok {
+		return nil
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.String:
+		if s, ok := v.Interface().(fmt.Stringer); ok {
+			return s.String()
+		}
+		return v.String()
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Invalid:
+		return nil
+	}
+	return v.Interface()
+}
+
+func truthy(v any) bool {
+	switch v := v.(type) {
+	case bool:
+		return v
+	case float64:
+		return v != 0
+	case string:
+		return v != ""
+	}
+	return v != nil
+}
+
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprint(v)
+}
+
+// convertValue converts the result of an expression to T.
+func convertValue[T any](v any) (res T) {
+	target := reflect.TypeFor[T]()
+	switch target.Kind() {
+	case reflect.String:
+		return reflect.ValueOf(formatValue(v)).Convert(target).Interface().(T)
+	case reflect.Bool:
+		return reflect.ValueOf(truthy(v)).Convert(target).Interface().(T)
+	}
+	value := reflect.ValueOf(v)
+	if value.IsValid() && value.Type().ConvertibleTo(target) {
+		return value.Convert(target).Interface().(T)
+	}
+	return
+}
+
+// newExpressionBinding creates a computed binding of type T for the template,
+// with the bindings it references as sources.
+func newExpressionBinding[T comparable](
+	t *template,
+	env map[string]types.Bindable,
+) *types.ComputedBinding[T] {
+	sources := make([]types.Bindable, 0, len(env))
+	for _, src := range env {
+		sources = append(sources, src)
+	}
+	return types.NewLazyComputedBinding(t.source, func() T {
+		return convertValue[T](t.eval(env))
+	}, sources...)
+}
+
+// expressionBinding parses the expression s and returns a binding of type
+// B (e.g. types.ValueBinding[string]) for it.
+func expressionBinding[B any](ctx types.Context, s string) (res B, err error) {
+	t, err := parseTemplate(s)
+	if err != nil {
+		return
+	}
+	env, err := resolveReferences(ctx, t)
+	if err != nil {
+		return
+	}
+
+	var b any
+	switch target := reflect.TypeFor[B](); {
+	case bindsAs[string](target):
+		b = newExpressionBinding[string](t, env)
+	case bindsAs[bool](target):
+		b = newExpressionBinding[bool](t, env)
+	case bindsAs[float32](target):
+		b = newExpressionBinding[float32](t, env)
+	case bindsAs[float64](target):
+		b = newExpressionBinding[float64](t, env)
+	case bindsAs[int](target):
+		b = newExpressionBinding[int](t, env)
+	default:
+		err = fmt.Errorf("an expression can not be bound as %s", target)
+		return
+	}
+	res = b.(B)
+	return
+}
+
+// bindsAs returns whether an expression binding of type T can be used as the
+// binding type target.
+func bindsAs[T comparable](target reflect.Type) bool {
+	return reflect.TypeFor[*types.ComputedBinding[T]]().AssignableTo(target)
+}
+
+// checkExpressions parses the binding expressions and translated messages of
+// the binding properties in defMap and resolves the bindings they reference,
+// so their errors are reported when the element is created, instead of
+// leaving the property unbound.
+func checkExpressions(ctx types.Context, schema []Property, defMap map[string]any) error {
+	var errs []error
+	for _, props := range [][]Property{commonProperties, schema} {
+		for _, prop := range props {
+			if prop.Kind != KindBinding {
+				continue
+			}
+			value := defMap[prop.Name]
+			s, isString := value.(string)
+			var err error
+			switch {
+			case IsTranslation(value):
+				var t translation
+				if t, err = parseTranslation(value); err == nil {
+					_, err = resolveArgs(ctx, t)
+				}
+			case isString && IsExpression(s):
+				var t *template
+				if t, err = parseTemplate(s); err == nil {
+					_, err = resolveReferences(ctx, t)
+				}
+			}
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", prop.Name, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// resolveReferences finds the bindings referenced by the template.
+func resolveReferences(ctx types.Context, t *template) (map[string]types.Bindable, error) {
+	env := make(map[string]types.Bindable, len(t.refs))
+	for _, ref := range t.refs {
+		if _, ok := env[ref]; ok {
+			continue
+		}
+		bnd := findBinding(ctx, ref)
+		if bnd == nil {
+			return nil, fmt.Errorf("no binding %q", ref)
+		}
+		env[ref] = bnd
+	}
+	return env, nil
+}
@@ -0,0 +1,22 @@
+// SPDX-License-Identifier: MIT
+
+package definition
+
+import (
+	"github.com/mheremans/goui/types"
+)
+
+// itemContext is the context of the elements created from an item template.
+// It resolves the binding references starting with a dot to the fields of
+// the item, all other references are resolved through the view.
+type itemContext struct {
+	types.Context
+	item *types.ItemBinding
+}
+
+// ItemContext returns a context for creating the elements of an item template
+// for item. In the template, `binding: .Name` refers to the field Name of the
+// item and `binding: .` to the item itself (see types.ItemBinding.Lookup).
+func ItemContext(ctx types.Context, item *types.ItemBinding) types.Context {
+	return &itemContext{Context: ctx, item: item}
+}
@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: MIT
+
+package definition
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// decodeJSON decodes a JSON definition, keeping the position of every value.
+func decodeJSON(data []byte) (*yaml.Node, error) {
+	d := &jsonDecoder{data: data, dec: json.NewDecoder(bytes.NewReader(data))}
+	d.dec.UseNumber()
+
+	root, err := d.value()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if syntaxErr, ok := err.(*json.SyntaxError); ok {
+		line, column := d.position(int(syntaxErr.Offset))
+		return nil, fmt.Errorf("line %d, column %d: %w", line, column, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, err := d.dec.Token(); err != io.EOF {
+		return nil, d.errorf("unexpected data after the definition")
+	}
+	return root, nil
+}
+
+type jsonDecoder struct {
+	data []byte
+	dec  *json.Decoder
+}
+
+// next returns the next token and the offset it starts at.
+func (d *jsonDecoder) next() (json.Token, int, error) {
+	offset := int(d.dec.InputOffset())
+	for offset < len(d.data) && strings.IndexByte(" \t\r\n,:", d.data[offset]) >= 0 {
+		offset++
+	}
+	tok, err := d.dec.Token()
+	return tok, offset, err
+}
+
+// value decodes the next value into a node.
+func (d *jsonDecoder) value() (*yaml.Node, error) {
+	tok, offset, err := d.next()
+	if err != nil {
+		return nil, err
+	}
+	node := &yaml.Node{Kind: yaml.ScalarNode}
+	node.Line, node.Column = d.position(offset)
+
+	switch tok := tok.(type) {
+	case json.Delim:
+		switch tok {
+		case '{':
+			node.Kind, node.Tag = yaml.MappingNode, "!!map"
+			for d.dec.More() {
+				key, err := d.value()
+				if err != nil {
+					return nil, err
+				}
+				value, err := d.value()
+				if err != nil {
+					return nil, err
+				}
+				node.Content = append(node.Content, key, value)
+			}
+		case '[':
+			node.Kind, node.Tag = yaml.SequenceNode, "!!seq"
+			for d.dec.More() {
+				value, err := d.value()
+				if err != nil {
+					return nil, err
+				}
+				node.Content = append(node.Content, value)
+			}
+		}
+		// Closing delimiter
+		if _, _, err := d.next(); err != nil {
+			return nil, err
+		}
+	case string:
+		node.Tag, node.Value, node.Style = "!!str", tok, yaml.DoubleQuotedStyle
+	case json.Number:
+		node.Tag, node.Value = "!!float", tok.String()
+		if _, err := tok.Int64(); err == nil {
+			node.Tag = "!!int"
+		}
+	case bool:
+		node.Tag, node.Value = "!!bool", fmt.Sprint(tok)
+	case nil:
+		node.Tag, node.Value = "!!null", "null"
+	}
+	return node, nil
+}
+
+// position returns the line and column of offset, like YAML nodes count them.
+func (d *jsonDecoder) position(offset int) (line int, column int) {
+	before := d.data[:min(offset, len(d.data))]
+	line = bytes.Count(before, []byte("\n")) + 1
+	column = utf8.RuneCount(before[bytes.LastIndexByte(before, '\n')+1:]) + 1
+	return
+}
+
+func (d *jsonDecoder) errorf(format string, args ...any) error {
+	line, column := d.position(int(d.dec.InputOffset()))
+	return fmt.Errorf("line %d, column %d: %s", line, column, fmt.Sprintf(format, args...))
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"image/color"
 	"strconv"
+	"strings"
 
 	giofont "gioui.org/font"
 	giolayout "gioui.org/layout"
@@ -197,6 +198,9 @@ func FunctionFromMap[T any](
 	return
 }
 
+// BindingFromMap resolves the binding referenced by key as T. The value may
+// also be a binding expression (see IsExpression), which is parsed into a
+// computed binding, or a translated message (see IsTranslation).
 func BindingFromMap[T types.Bindable](
 	ctx types.Context,
 	data map[string]any,
@@ -205,11 +209,23 @@ func BindingFromMap[T types.Bindable](
 	res T,
 	ok bool,
 ) {
+	if IsTranslation(data[key]) {
+		var err error
+		res, err = translationBinding[T](ctx, data[key])
+		ok = err == nil
+		return
+	}
 	sv, ok := MapValueString[string](data, key)
 	if !ok {
 		return
 	}
-	bnd := ctx.View().FindBinding(sv)
+	if IsExpression(sv) {
+		var err error
+		res, err = expressionBinding[T](ctx, sv)
+		ok = err == nil
+		return
+	}
+	bnd := findBinding(ctx, sv)
 	if bnd == nil {
 		ok = false
 		return
@@ -218,6 +234,117 @@ func BindingFromMap[T types.Bindable](
 	return
 }
 
+// BoolBindingFromMap resolves the boolean binding referenced by key. A
+// reference prefixed with "!" (e.g. `!Boiling`) resolves to the inverse of the
+// binding (see types.Not).
+func BoolBindingFromMap(
+	ctx types.Context,
+	data map[string]any,
+	key string,
+) (
+	res types.ValueBinding[bool],
+	ok bool,
+) {
+	sv, ok := MapValueString[string](data, key)
+	if !ok {
+		return
+	}
+	if IsExpression(sv) {
+		var err error
+		res, err = expressionBinding[types.ValueBinding[bool]](ctx, sv)
+		ok = err == nil
+		return
+	}
+	ref, inverted := strings.CutPrefix(sv, "!")
+	res, ok = findBinding(ctx, ref).(types.ValueBinding[bool])
+	if ok && inverted {
+		res = types.Not(res)
+	}
+	return
+}
+
+// findBinding requests a binding through the view.
+//
+// A reference of the form `Name[key]` resolves to the entry `key` of the
+// keyed binding (e.g. a types.MapBinding) called `Name`. A reference starting
+// with a dot resolves to a field of the item in an item context (see
+// ItemContext), e.g. `.Name`.
+func findBinding(ctx types.Context, ref string) types.Bindable {
+	if strings.HasPrefix(ref, ".") {
+		ic, ok := ctx.(*itemContext)
+		if !ok {
+			return nil
+		}
+		if bnd, ok := ic.item.Lookup(ref[1:]); ok {
+			return bnd
+		}
+		return nil
+	}
+	if bnd := ctx.View().FindBinding(ref); bnd != nil {
+		return bnd
+	}
+	name, key, keyed := splitBindingRef(ref)
+	if !keyed {
+		return nil
+	}
+	kb, ok := ctx.View().FindBinding(name).(types.KeyedBindable)
+	if !ok {
+		return nil
+	}
+	if entry, ok := kb.Lookup(key); ok {
+		return entry
+	}
+	return nil
+}
+
+func splitBindingRef(ref string) (name string, key string, keyed bool) {
+	open := strings.Index(ref, "[")
+	if open < 0 || !strings.HasSuffix(ref, "]") {
+		return ref, "", false
+	}
+	return ref[:open], ref[open+1 : len(ref)-1], true
+}
+
+// ConvertedBindingFromMap resolves the binding referenced by bindingKey as a
+// MutableBinding[T].
+//
+// When the data contains a converterKey, the converter is requested through
+// the view (like FunctionFromMap) and must implement types.BindingAdapter[T].
+// It is then used to adapt a binding of any type to T.
+func ConvertedBindingFromMap[T any](
+	ctx types.Context,
+	data map[string]any,
+	bindingKey string,
+	converterKey string,
+) (
+	res types.MutableBinding[T],
+	ok bool,
+) {
+	if _, ok = data[converterKey]; !ok {
+		return BindingFromMap[types.MutableBinding[T]](ctx, data, bindingKey)
+	}
+
+	adapter, ok := FunctionFromMap[types.BindingAdapter[T]](ctx, data, converterKey)
+	if !ok {
+		return
+	}
+	bnd, ok := BindingFromMap[types.Bindable](ctx, data, bindingKey)
+	if !ok {
+		return
+	}
+	return adapter.Adapt(bnd)
+}
+
+// GioConstantName returns the name of a gio constant as written in a
+// definition, the reverse of GioConstantFromMap.
+func GioConstantName(value fmt.Stringer) string {
+	// Work around gio bug, see findGioConstant
+	if spacing, ok := value.(giolayout.Spacing); ok && spacing == giolayout.SpaceBetween {
+		return "SpaceBetween"
+	}
+	return value.String()
+}
+
 func findGioConstant[T gioConst](name string) (res T, ok bool) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -5,27 +5,194 @@ package definition
 import (
 	"fmt"
 	"reflect"
+	"slices"
 	"strings"
+	"sync"
 
 	"github.com/mheremans/goui/types"
 )
 
 type ConstructorFn func(types.Context, map[string]any) (DefinitionType, error)
 
-var uiElementRegistry map[string]ConstructorFn
+type registryEntry struct {
+	name        string // Name the element was registered under
+	typ         reflect.Type
+	constructor ConstructorFn
+	schema      []Property
+}
+
+// Registry holds the elements that can be used in definitions, by their type
+// name.
+//
+// The elements of the packages of goui (and of other packages calling
+// RegisterUIElement) are registered in the default registry, which is used
+// unless a definition is loaded with Options.Registry. A registry with a
+// parent falls back to the elements of its parent, so tests can register mock
+// elements (or replace registered ones) without changing the default
+// registry.
+type Registry struct {
+	parent *Registry
+
+	mu      sync.RWMutex
+	entries map[string]*registryEntry // By name and alias
+	types   map[reflect.Type]*registryEntry
+}
+
+// defaultRegistry holds the elements registered with RegisterUIElement.
+var defaultRegistry = NewRegistry(nil)
 
-func init() {
-	uiElementRegistry = make(map[string]ConstructorFn)
+// NewRegistry creates an empty registry, which falls back to the elements of
+// parent when it is not nil, e.g. NewRegistry(DefaultRegistry()).
+func NewRegistry(parent *Registry) *Registry {
+	return &Registry{
+		parent:  parent,
+		entries: make(map[string]*registryEntry),
+		types:   make(map[reflect.Type]*registryEntry),
+	}
 }
 
-func RegisterUIElement(e types.UIElement, constructor ConstructorFn) {
+// DefaultRegistry returns the registry of the elements registered with
+// RegisterUIElement.
+func DefaultRegistry() *Registry {
+	return defaultRegistry
+}
+
+// DerivedTypeName returns the type name of the element e, as derived from its
+// Go type: the package path and the type name, e.g.
+// "github.com/acme/ui/widgets.Gauge". The elements of goui have the prefix
+// "github.com/mheremans/goui/" trimmed, e.g. "widget.Label".
+func DerivedTypeName(e types.UIElement) string {
 	t := reflect.TypeOf(e).Elem()
 	tn := strings.TrimPrefix(t.PkgPath(), "github.com/mheremans/goui/")
-	tn = tn + "." + t.Name()
-	uiElementRegistry[tn] = constructor
+	return tn + "." + t.Name()
 }
 
-func Instantiate(
+// Register registers an element under the name derived from its Go type (see
+// DerivedTypeName).
+func (r *Registry) Register(
+	e types.UIElement,
+	constructor ConstructorFn,
+	properties ...Property,
+) error {
+	return r.RegisterAs(DerivedTypeName(e), e, constructor, properties...)
+}
+
+// RegisterAs registers an element under an explicit type name, e.g.
+// "acme.Gauge". The properties are the schema of the element, used to
+// validate definitions. Elements registered without properties are not
+// validated beyond the common properties.
+//
+// Registering a name that is already used in r is an error. A name of the
+// parent registry may be used, the element then replaces the element of the
+// parent in r.
+func (r *Registry) RegisterAs(
+	name string,
+	e types.UIElement,
+	constructor ConstructorFn,
+	properties ...Property,
+) error {
+	if err := checkTypeName(name); err != nil {
+		return err
+	}
+	t := reflect.TypeOf(e)
+	if t == nil || t.Kind() != reflect.Pointer {
+		return fmt.Errorf("element %s: %T is not a pointer type", name, e)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.entries[name]; ok {
+		return fmt.Errorf("element %s is already registered for %s", name, existing.typ)
+	}
+	entry := &registryEntry{
+		name:        name,
+		typ:         t.Elem(),
+		constructor: constructor,
+		schema:      properties,
+	}
+	r.entries[name] = entry
+	if _, ok := r.types[entry.typ]; !ok {
+		r.types[entry.typ] = entry
+	}
+	return nil
+}
+
+// Alias makes the element registered as name (in r or its parent) available
+// under the type name alias as well, e.g. for an element that was renamed.
+// Serialize writes the name, not the alias.
+func (r *Registry) Alias(alias string, name string) error {
+	if err := checkTypeName(alias); err != nil {
+		return err
+	}
+	entry, ok := r.lookup(name)
+	if !ok {
+		return fmt.Errorf("alias %s: no such element: %s", alias, name)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.entries[alias]; ok {
+		return fmt.Errorf("alias %s: element %s is already registered for %s",
+			alias, alias, existing.typ)
+	}
+	r.entries[alias] = entry
+	return nil
+}
+
+// Names returns the type names and aliases of the elements of r and its
+// parent, sorted.
+func (r *Registry) Names() []string {
+	var names []string
+	for reg := r; reg != nil; reg = reg.parent {
+		reg.mu.RLock()
+		for name := range reg.entries {
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+		reg.mu.RUnlock()
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Has returns whether an element is registered as name.
+func (r *Registry) Has(name string) bool {
+	_, ok := r.lookup(name)
+	return ok
+}
+
+// Schema returns the properties of a registered element.
+func (r *Registry) Schema(name string) (properties []Property, ok bool) {
+	entry, ok := r.lookup(name)
+	if !ok {
+		return
+	}
+	properties = entry.schema
+	return
+}
+
+// TypeName returns the name elem is registered under, i.e. its `type` in a
+// definition.
+func (r *Registry) TypeName(elem types.UIElement) (string, bool) {
+	t := reflect.TypeOf(elem)
+	if t == nil || t.Kind() != reflect.Pointer {
+		return "", false
+	}
+	for reg := r; reg != nil; reg = reg.parent {
+		reg.mu.RLock()
+		entry, ok := reg.types[t.Elem()]
+		reg.mu.RUnlock()
+		if ok {
+			return entry.name, true
+		}
+	}
+	return "", false
+}
+
+// Instantiate creates the element registered as name from the properties in
+// data.
+func (r *Registry) Instantiate(
 	ctx types.Context,
 	name string,
 	data map[string]any,
@@ -33,15 +200,134 @@ func Instantiate(
 	res DefinitionType,
 	err error,
 ) {
-	constructor, ok := uiElementRegistry[name]
+	entry, ok := r.lookup(name)
 	if !ok {
 		err = fmt.Errorf("no such element: %s", name)
 		return
 	}
 
-	res, err = constructor(ctx, data)
+	res, err = entry.constructor(ctx, data)
 	if err != nil {
 		return
 	}
 	return
 }
+
+func (r *Registry) lookup(name string) (*registryEntry, bool) {
+	for reg := r; reg != nil; reg = reg.parent {
+		reg.mu.RLock()
+		entry, ok := reg.entries[name]
+		reg.mu.RUnlock()
+		if ok {
+			return entry, true
+		}
+	}
+	return nil, false
+}
+
+// checkTypeName returns an error when name can not be used as the type of an
+// element in a definition.
+func checkTypeName(name string) error {
+	switch {
+	case name == "":
+		return fmt.Errorf("element has an empty type name")
+	case name == slotType:
+		return fmt.Errorf("element can not be registered as %s", slotType)
+	case strings.ContainsAny(name, " \t\n$"):
+		return fmt.Errorf("element type name %q contains invalid characters", name)
+	}
+	return nil
+}
+
+// RegisterUIElement registers an element in the default registry, under the
+// name derived from its Go type (see DerivedTypeName), so it can be used in
+// definitions. It is meant to be called from an init function and panics
+// when the name is already registered.
+//
+// The properties are the schema of the element, used to validate
+// definitions. Elements registered without properties are not validated
+// beyond the common properties.
+func RegisterUIElement(
+	e types.UIElement,
+	constructor ConstructorFn,
+	properties ...Property,
+) {
+	if err := defaultRegistry.Register(e, constructor, properties...); err != nil {
+		panic(err)
+	}
+}
+
+// RegisterUIElementAs is like RegisterUIElement, with an explicit type name,
+// e.g. "acme.Gauge" instead of "github.com/acme/ui/widgets.Gauge".
+func RegisterUIElementAs(
+	name string,
+	e types.UIElement,
+	constructor ConstructorFn,
+	properties ...Property,
+) {
+	if err := defaultRegistry.RegisterAs(name, e, constructor, properties...); err != nil {
+		panic(err)
+	}
+}
+
+// RegisterAlias makes an element of the default registry available under the
+// type name alias as well (see Registry.Alias). It panics when the alias is
+// already registered or name is not.
+func RegisterAlias(alias string, name string) {
+	if err := defaultRegistry.Alias(alias, name); err != nil {
+		panic(err)
+	}
+}
+
+// Schema returns the properties of an element of the default registry.
+func Schema(name string) (properties []Property, ok bool) {
+	return defaultRegistry.Schema(name)
+}
+
+// TypeName returns the name elem is registered under in the default registry,
+// i.e. its `type` in a definition.
+func TypeName(elem types.UIElement) (string, bool) {
+	return defaultRegistry.TypeName(elem)
+}
+
+// Instantiate creates an element of the default registry.
+func Instantiate(
+	ctx types.Context,
+	name string,
+	data map[string]any,
+) (
+	res DefinitionType,
+	err error,
+) {
+	return defaultRegistry.Instantiate(ctx, name, data)
+}
+
+// registryContext is the context the elements of a definition loaded with
+// Options.Registry are created with, so the item templates of a list (see
+// NewFromMap) use the same registry.
+type registryContext struct {
+	types.Context
+	registry *Registry
+}
+
+// registryOf returns the registry elements are created from in ctx.
+func registryOf(ctx types.Context) *Registry {
+	for {
+		switch c := ctx.(type) {
+		case *registryContext:
+			return c.registry
+		case *itemContext:
+			ctx = c.Context
+		default:
+			return defaultRegistry
+		}
+	}
+}
+
+// withRegistry returns ctx with the registry r, see registryOf.
+func withRegistry(ctx types.Context, r *Registry) types.Context {
+	if r == nil || r == registryOf(ctx) {
+		return ctx
+	}
+	return &registryContext{Context: ctx, registry: r}
+}
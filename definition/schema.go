@@ -0,0 +1,574 @@
+// SPDX-License-Identifier: MIT
+
+package definition
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mheremans/goui/colors"
+	"github.com/mheremans/goui/types"
+	"gopkg.in/yaml.v3"
+)
+
+type PropertyKind uint8
+
+const (
+	KindString   PropertyKind = iota // Any scalar
+	KindNumber                       // Integer or floating point number
+	KindBool                         // true or false
+	KindColor                        // Color name or hex value (see colors.GetColor)
+	KindEnum                         // One of Property.Values
+	KindFunction                     // Name of a function exported by the view
+	KindBinding                      // Name of a binding of the view model
+	KindChild                        // A single child element
+	KindChildren                     // A list of child elements
+)
+
+func (k PropertyKind) String() string {
+	return [...]string{
+		"string",
+		"number",
+		"bool",
+		"color",
+		"enum",
+		"function",
+		"binding",
+		"element",
+		"list of elements"}[k]
+}
+
+// Property describes a property of an element in a definition.
+//
+// The schema of an element is the list of its properties, declared when it is
+// registered with RegisterUIElement. The properties `type`, `id` and `weight`
+// are common to all elements and never need to be declared.
+type Property struct {
+	Name     string
+	Kind     PropertyKind
+	Required bool
+	Values   []string     // Allowed values of a KindEnum property
+	Type     reflect.Type // Go type of a KindFunction or KindBinding property
+	Binding  string       // Binding property an expression value is moved to
+}
+
+// AsRequired returns a copy of the property that must be present.
+func (p Property) AsRequired() Property {
+	p.Required = true
+	return p
+}
+
+// BindsTo returns a copy of the property whose value may be a binding
+// expression (see IsExpression), e.g. `text: "{Count} items"`. The expression
+// is used as the value of the binding property named binding.
+func (p Property) BindsTo(binding string) Property {
+	p.Binding = binding
+	return p
+}
+
+func StringProperty(name string) Property {
+	return Property{Name: name, Kind: KindString}
+}
+
+func NumberProperty(name string) Property {
+	return Property{Name: name, Kind: KindNumber}
+}
+
+func BoolProperty(name string) Property {
+	return Property{Name: name, Kind: KindBool}
+}
+
+func ColorProperty(name string) Property {
+	return Property{Name: name, Kind: KindColor}
+}
+
+func EnumProperty(name string, values ...string) Property {
+	return Property{Name: name, Kind: KindEnum, Values: values}
+}
+
+// ConstantProperty is an enum property whose values are the names of the gio
+// constants of type T (see GioConstantFromMap).
+func ConstantProperty[T gioConst](name string) Property {
+	return Property{Name: name, Kind: KindEnum, Values: gioConstantNames[T]()}
+}
+
+// FunctionProperty is the name of a function of type T exported by the view
+// (see FunctionFromMap).
+func FunctionProperty[T any](name string) Property {
+	return Property{Name: name, Kind: KindFunction, Type: reflect.TypeFor[T]()}
+}
+
+// BindingProperty is the name of a binding of type T (see BindingFromMap).
+func BindingProperty[T any](name string) Property {
+	return Property{Name: name, Kind: KindBinding, Type: reflect.TypeFor[T]()}
+}
+
+// ChildProperty is the `child` of a layout with a single child.
+func ChildProperty() Property {
+	return Property{Name: "child", Kind: KindChild}
+}
+
+// TemplateProperty is an element that is created for every item of a list,
+// see ItemContext.
+func TemplateProperty(name string) Property {
+	return Property{Name: name, Kind: KindChild}
+}
+
+// ChildrenProperty is the `children` list of a layout.
+func ChildrenProperty() Property {
+	return Property{Name: "children", Kind: KindChildren}
+}
+
+// commonProperties are supported by every element
+var commonProperties = []Property{
+	StringProperty("type").AsRequired(),
+	StringProperty("id"),
+	NumberProperty("weight"),
+	BindingProperty[types.ValueBinding[bool]]("visible"),
+	BindingProperty[types.ValueBinding[bool]]("enabled"),
+}
+
+// Error is an error in a definition, at the location of the offending YAML
+// node.
+type Error struct {
+	File    string
+	Line    int
+	Column  int
+	Message string
+}
+
+// Error formats the error as file:line:column: message, or as file: message
+// when the position is unknown (see Decoder).
+func (e Error) Error() string {
+	if e.Line == 0 {
+		return fmt.Sprintf("%s: %s", e.File, e.Message)
+	}
+	return fmt.Sprintf("%s:%d:%d: %s", e.File, e.Line, e.Column, e.Message)
+}
+
+// Errors is the list of all errors found in a definition.
+type Errors []*Error
+
+func (e Errors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// add adds err, unless the same error was already added. Elements of a
+// component are checked once per instance, which would repeat their errors.
+func (e *Errors) add(err *Error) {
+	for _, other := range *e {
+		if *other == *err {
+			return
+		}
+	}
+	*e = append(*e, err)
+}
+
+// validator checks a definition against the schemas of the registered
+// elements.
+type validator struct {
+	ctx      types.Context
+	registry *Registry
+	file     string
+	strict   bool
+	origin   map[*yaml.Node]string // File of nodes from included definitions
+	errs     Errors
+}
+
+func (v *validator) errorf(node *yaml.Node, format string, args ...any) {
+	v.errs.add(&Error{
+		File:    v.fileOf(node),
+		Line:    node.Line,
+		Column:  node.Column,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// fileOf returns the file node was read from.
+func (v *validator) fileOf(node *yaml.Node) string {
+	if f, ok := v.origin[node]; ok {
+		return f
+	}
+	return v.file
+}
+
+// validateElement validates an element node and all of its children.
+func (v *validator) validateElement(node *yaml.Node) {
+	if node.Kind != yaml.MappingNode {
+		v.errorf(node, "element must be a mapping, got %s", nodeKindName(node))
+		return
+	}
+
+	typeNode := mappingValue(node, "type")
+	if typeNode == nil {
+		v.errorf(node, "element has no type")
+		return
+	}
+	entry, ok := v.registry.lookup(typeNode.Value)
+	if !ok {
+		v.errorf(typeNode, "no such element: %s", typeNode.Value)
+		return
+	}
+
+	seen := make(map[string]bool)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		seen[key.Value] = true
+
+		prop, ok := findProperty(entry.schema, key.Value)
+		if !ok {
+			if v.strict && entry.schema != nil {
+				v.errorf(key, "unknown property %q for %s", key.Value, typeNode.Value)
+			}
+			continue
+		}
+		v.validateProperty(node, prop, value)
+	}
+
+	for _, prop := range entry.schema {
+		if prop.Required && !seen[prop.Name] {
+			v.errorf(node, "missing required property %q for %s",
+				prop.Name, typeNode.Value)
+		}
+	}
+}
+
+func (v *validator) validateProperty(element *yaml.Node, prop Property, node *yaml.Node) {
+	switch prop.Kind {
+	case KindChild:
+		v.validateElement(node)
+		return
+	case KindChildren:
+		if node.Kind != yaml.SequenceNode {
+			v.errorf(node, "%s must be a list of elements, got %s",
+				prop.Name, nodeKindName(node))
+			return
+		}
+		for _, child := range node.Content {
+			v.validateElement(child)
+		}
+		return
+	}
+
+	if isTranslationNode(node) && (prop.Binding != "" || prop.Kind == KindBinding) {
+		if binding, ok := v.movedProperty(element, prop, node, "translation"); ok {
+			v.validateTranslation(binding, node)
+		}
+		return
+	}
+
+	if node.Kind != yaml.ScalarNode {
+		v.errorf(node, "%s must be a %s, got %s", prop.Name, prop.Kind, nodeKindName(node))
+		return
+	}
+
+	if prop.Binding != "" && IsExpression(node.Value) {
+		if binding, ok := v.movedProperty(element, prop, node, "expression"); ok {
+			v.validateExpression(binding, node)
+		}
+		return
+	}
+
+	switch prop.Kind {
+	case KindNumber:
+		if _, err := strconv.ParseFloat(node.Value, 64); err != nil {
+			v.errorf(node, "%s must be a number, got %q", prop.Name, node.Value)
+		}
+	case KindBool:
+		if _, err := strconv.ParseBool(node.Value); err != nil {
+			v.errorf(node, "%s must be true or false, got %q", prop.Name, node.Value)
+		}
+	case KindColor:
+		if node.Value == "" {
+			v.errorf(node, "%s must be a color", prop.Name)
+		} else if _, err := colors.GetColor(node.Value); err != nil {
+			v.errorf(node, "%s is not a valid color: %q", prop.Name, node.Value)
+		}
+	case KindEnum:
+		if !slices.Contains(prop.Values, node.Value) {
+			v.errorf(node, "%s must be one of %s, got %q",
+				prop.Name, strings.Join(prop.Values, ", "), node.Value)
+		}
+	case KindFunction:
+		v.validateFunction(prop, node)
+	case KindBinding:
+		// A converter adapts the binding, so its type can not be checked
+		if mappingValue(element, "converter") != nil {
+			prop.Type = nil
+		}
+		v.validateBinding(prop, node)
+	}
+}
+
+// movedProperty returns the binding property an expression or translation
+// (what) of prop is moved to (see Property.BindsTo), named after prop. A
+// binding property is returned as is.
+func (v *validator) movedProperty(
+	element *yaml.Node,
+	prop Property,
+	node *yaml.Node,
+	what string,
+) (Property, bool) {
+	if prop.Binding == "" {
+		return prop, true
+	}
+	typeNode := mappingValue(element, "type")
+	schema, _ := v.registry.Schema(typeNode.Value)
+	binding, ok := findProperty(schema, prop.Binding)
+	if !ok {
+		return binding, false
+	}
+	if mappingValue(element, prop.Binding) != nil {
+		v.errorf(node, "%s: %s conflicts with %s", prop.Name, what, prop.Binding)
+		return binding, false
+	}
+	binding.Name = prop.Name
+	return binding, true
+}
+
+func (v *validator) validateFunction(prop Property, node *yaml.Node) {
+	if v.ctx == nil || v.ctx.View() == nil {
+		return
+	}
+	fn := v.ctx.View().FindFunction(node.Value)
+	if fn == nil {
+		v.errorf(node, "%s: no function %q exported by the view", prop.Name, node.Value)
+		return
+	}
+	if !matchesType(reflect.TypeOf(fn), prop.Type) {
+		v.errorf(node, "%s: function %q has type %T, expected %s",
+			prop.Name, node.Value, fn, prop.Type)
+	}
+}
+
+func (v *validator) validateBinding(prop Property, node *yaml.Node) {
+	if v.ctx == nil || v.ctx.View() == nil {
+		return
+	}
+	if IsExpression(node.Value) {
+		v.validateExpression(prop, node)
+		return
+	}
+	// Fields of an item only exist when the item template is instantiated
+	if strings.HasPrefix(strings.TrimPrefix(node.Value, "!"), ".") {
+		return
+	}
+	ref := node.Value
+	if strings.HasPrefix(ref, "!") {
+		// Inverted boolean binding (see BoolBindingFromMap)
+		ref = ref[1:]
+		prop.Type = reflect.TypeFor[types.ValueBinding[bool]]()
+	}
+	bnd := findBinding(v.ctx, ref)
+	if bnd == nil {
+		v.errorf(node, "%s: no binding %q", prop.Name, node.Value)
+		return
+	}
+	if !matchesType(reflect.TypeOf(bnd), prop.Type) {
+		v.errorf(node, "%s: binding %q has type %T, expected %s",
+			prop.Name, node.Value, bnd, prop.Type)
+	}
+}
+
+// validateExpression validates a binding expression, for the binding
+// property prop.
+func (v *validator) validateExpression(prop Property, node *yaml.Node) {
+	t, err := parseTemplate(node.Value)
+	if err != nil {
+		if exprErr, ok := err.(*ExpressionError); ok {
+			v.errs.add(&Error{
+				File:    v.fileOf(node),
+				Line:    node.Line,
+				Column:  node.Column + exprErr.Offset + quoteWidth(node),
+				Message: fmt.Sprintf("%s: %s", prop.Name, exprErr.Message),
+			})
+			return
+		}
+		v.errorf(node, "%s: %v", prop.Name, err)
+		return
+	}
+
+	if prop.Type != nil && !bindsAs[string](prop.Type) && !bindsAs[bool](prop.Type) &&
+		!bindsAs[float32](prop.Type) && !bindsAs[float64](prop.Type) &&
+		!bindsAs[int](prop.Type) {
+		v.errorf(node, "%s: an expression can not be bound as %s", prop.Name, prop.Type)
+		return
+	}
+
+	if v.ctx == nil || v.ctx.View() == nil {
+		return
+	}
+	for _, ref := range t.refs {
+		// Fields of an item only exist when the item template is instantiated
+		if strings.HasPrefix(ref, ".") {
+			continue
+		}
+		if findBinding(v.ctx, ref) == nil {
+			v.errorf(node, "%s: no binding %q", prop.Name, ref)
+		}
+	}
+}
+
+// validateTranslation validates a translated message (see IsTranslation), for
+// the binding property prop.
+func (v *validator) validateTranslation(prop Property, node *yaml.Node) {
+	if prop.Type != nil && !bindsAs[string](prop.Type) {
+		v.errorf(node, "%s: a translation can not be bound as %s", prop.Name, prop.Type)
+		return
+	}
+
+	key := strings.TrimPrefix(node.Value, "@")
+	var args *yaml.Node
+	if node.Kind == yaml.MappingNode {
+		key = ""
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			name, value := node.Content[i], node.Content[i+1]
+			switch name.Value {
+			case "tr":
+				if value.Kind != yaml.ScalarNode || value.Value == "" {
+					v.errorf(value, "%s: tr must be a message key", prop.Name)
+					return
+				}
+				key = value.Value
+			case "args":
+				if value.Kind != yaml.MappingNode {
+					v.errorf(value, "%s: args must be a mapping of arguments, got %s",
+						prop.Name, nodeKindName(value))
+					return
+				}
+				args = value
+			default:
+				v.errorf(name, "%s: unknown translation property %q", prop.Name, name.Value)
+			}
+		}
+	}
+	if key == "" {
+		v.errorf(node, "%s: missing message key", prop.Name)
+		return
+	}
+
+	if v.ctx == nil {
+		return
+	}
+	if l := localizer(v.ctx); len(l.Bundle().Locales()) > 0 && !l.Has(key) {
+		v.errorf(node, "%s: no message %q", prop.Name, key)
+	}
+
+	if args == nil || v.ctx.View() == nil {
+		return
+	}
+	for i := 1; i < len(args.Content); i += 2 {
+		arg := args.Content[i]
+		if arg.Kind != yaml.ScalarNode {
+			v.errorf(arg, "%s: argument %s must be a binding or a value, got %s",
+				prop.Name, args.Content[i-1].Value, nodeKindName(arg))
+			continue
+		}
+		// Only strings reference bindings, fields of an item only exist when
+		// the item template is instantiated
+		if arg.ShortTag() != "!!str" || strings.HasPrefix(arg.Value, ".") {
+			continue
+		}
+		if findBinding(v.ctx, arg.Value) == nil {
+			v.errorf(arg, "%s: no binding %q", prop.Name, arg.Value)
+		}
+	}
+}
+
+// isTranslationNode returns whether node holds a translated message (see
+// IsTranslation).
+func isTranslationNode(node *yaml.Node) bool {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return node.ShortTag() == "!!str" && IsTranslation(node.Value)
+	case yaml.MappingNode:
+		return mappingValue(node, "tr") != nil
+	}
+	return false
+}
+
+// quoteWidth returns the width of the opening quote of a scalar node.
+func quoteWidth(node *yaml.Node) int {
+	if node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0 {
+		return 1
+	}
+	return 0
+}
+
+// matchesType returns whether a value of type t can be used as expected.
+func matchesType(t reflect.Type, expected reflect.Type) bool {
+	if expected == nil {
+		return true
+	}
+	if expected.Kind() == reflect.Interface {
+		return t.Implements(expected)
+	}
+	return t.AssignableTo(expected)
+}
+
+func findProperty(schema []Property, name string) (Property, bool) {
+	for _, props := range [][]Property{commonProperties, schema} {
+		for _, p := range props {
+			if p.Name == name {
+				return p, true
+			}
+		}
+	}
+	return Property{}, false
+}
+
+// mappingValue returns the value node of key in a mapping node.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func nodeKindName(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		return "a list"
+	case yaml.MappingNode:
+		return "a mapping"
+	case yaml.AliasNode:
+		return "an alias"
+	default:
+		return fmt.Sprintf("%q", node.Value)
+	}
+}
+
+// gioConstantNames returns the names of the gio constants of type T, by
+// iterating over the values until String panics (see findGioConstant).
+func gioConstantNames[T gioConst]() (names []string) {
+	defer func() {
+		if r := recover(); r != nil {
+			// Work around gio bug, see findGioConstant
+			if slices.Contains(names, "SpaceAround") &&
+				!slices.Contains(names, "SpaceBetween") {
+				names = append(names, "SpaceBetween")
+			}
+		}
+	}()
+
+	var t T
+	for i := 0; i < 256; i++ {
+		if name := t.String(); !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+		t++
+	}
+	return
+}
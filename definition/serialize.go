@@ -0,0 +1,390 @@
+// SPDX-License-Identifier: MIT
+
+package definition
+
+import (
+	"bytes"
+	"fmt"
+	"image/color"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mheremans/goui/types"
+	"gopkg.in/yaml.v3"
+)
+
+// Serializable is implemented by elements that can be written back to a
+// definition, see Serialize.
+type Serializable interface {
+	// Properties returns the properties of the element as they are written in
+	// a definition, except for the common properties and the children.
+	// Values are converted by Serialize: bindings are written as their name,
+	// functions and commands as the name they are exported under by the view,
+	// constants as their name and zero values are left out.
+	Properties() map[string]any
+}
+
+// Parent is implemented by layouts, to write their children to a definition.
+type Parent interface {
+	Children() []Child
+}
+
+// Child is a child element of a layout, with its weight (nil for elements
+// without a weight).
+type Child struct {
+	Element types.UIElement
+	Weight  *float32
+}
+
+// SerializeOptions configures how elements are written to a definition.
+type SerializeOptions struct {
+	// FunctionName returns the name a function (e.g. a callback or a
+	// converter) is exported under by the view. Functions without a name are
+	// left out.
+	FunctionName func(fn any) (string, bool)
+
+	// Registry holds the elements of the tree, the default registry (see
+	// RegisterUIElement) when nil.
+	Registry *Registry
+
+	// Imports are the namespaces by prefix written under the `imports` key of
+	// the definition. Types in one of the namespaces are written with the
+	// prefix instead, e.g. acme.Gauge.
+	Imports map[string]string
+}
+
+// Serialize writes the element tree root as a YAML definition, the reverse of
+// New. Creating the definition again results in an equivalent element tree.
+//
+// Elements must be registered (see Registry) and implement
+// Serializable, layouts also implement Parent. Ids generated for elements
+// without an id are left out. Translated messages are written as "@key",
+// without their arguments.
+func Serialize(root types.UIElement, options SerializeOptions) ([]byte, error) {
+	node, err := SerializeNode(root, options)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(node); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// SerializeNode is like Serialize, returning the YAML nodes of the definition.
+func SerializeNode(root types.UIElement, options SerializeOptions) (*yaml.Node, error) {
+	s := serializer{options: options, registry: options.Registry}
+	if s.registry == nil {
+		s.registry = defaultRegistry
+	}
+	node, err := s.element(root, nil)
+	if err != nil || len(options.Imports) == 0 {
+		return node, err
+	}
+
+	imports := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	prefixes := make([]string, 0, len(options.Imports))
+	for prefix := range options.Imports {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+	for _, prefix := range prefixes {
+		imports.Content = append(imports.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: prefix},
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: options.Imports[prefix]})
+	}
+	node.Content = append([]*yaml.Node{
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: "imports"}, imports,
+	}, node.Content...)
+	return node, nil
+}
+
+// conditionBindings is implemented by elements embedding types.Conditions.
+type conditionBindings interface {
+	VisibleBinding() types.ValueBinding[bool]
+	EnabledBinding() types.ValueBinding[bool]
+}
+
+type serializer struct {
+	options  SerializeOptions
+	registry *Registry
+}
+
+func (s serializer) element(elem types.UIElement, weight *float32) (*yaml.Node, error) {
+	typeName, ok := s.registry.TypeName(elem)
+	if !ok {
+		return nil, fmt.Errorf("element %T is not registered", elem)
+	}
+	serializable, ok := elem.(Serializable)
+	if !ok {
+		return nil, fmt.Errorf("element %s can not be serialized", typeName)
+	}
+
+	node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	add := func(name string, value any) error {
+		valueNode, ok := value.(*yaml.Node)
+		if !ok {
+			valueNode = new(yaml.Node)
+			if err := valueNode.Encode(value); err != nil {
+				return fmt.Errorf("%s: %s: %w", typeName, name, err)
+			}
+		}
+		node.Content = append(node.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name}, valueNode)
+		return nil
+	}
+
+	add("type", s.importedType(typeName))
+	if id := elem.ID(); id != "" && uuid.Validate(id) != nil {
+		add("id", id)
+	}
+	if weight != nil {
+		add("weight", *weight)
+	}
+
+	properties := serializable.Properties()
+	if c, ok := elem.(conditionBindings); ok {
+		if b := c.VisibleBinding(); b != nil {
+			properties["visible"] = b
+		}
+		if b := c.EnabledBinding(); b != nil {
+			properties["enabled"] = b
+		}
+	}
+	// A converted binding is written with its converter
+	if b, ok := properties["binding"].(types.Bindable); ok {
+		if converter, ok := converterOf(b); ok && properties["converter"] == nil {
+			properties["converter"] = converter
+		}
+	}
+
+	schema, _ := s.registry.Schema(typeName)
+	values := make(map[string]any, len(properties))
+	for name, property := range properties {
+		if value, ok := s.value(property); ok {
+			values[name] = value
+		}
+	}
+	moveBindings(schema, values)
+	for _, name := range propertyOrder(schema, values) {
+		value := values[name]
+		if template, ok := value.(map[string]any); ok {
+			value = s.templateNode(template)
+		}
+		if err := add(name, value); err != nil {
+			return nil, err
+		}
+	}
+
+	parent, ok := elem.(Parent)
+	if !ok {
+		return node, nil
+	}
+	children := parent.Children()
+	if slices.ContainsFunc(schema, func(p Property) bool {
+		return p.Name == "child" && p.Kind == KindChild
+	}) {
+		if len(children) > 0 && children[0].Element != nil {
+			child, err := s.element(children[0].Element, nil)
+			if err != nil {
+				return nil, err
+			}
+			add("child", child)
+		}
+		return node, nil
+	}
+	if len(children) > 0 {
+		list := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for _, c := range children {
+			child, err := s.element(c.Element, c.Weight)
+			if err != nil {
+				return nil, err
+			}
+			list.Content = append(list.Content, child)
+		}
+		add("children", list)
+	}
+	return node, nil
+}
+
+// value converts a property value to the value written in the definition.
+// It returns false for values that are left out.
+func (s serializer) value(value any) (any, bool) {
+	if value == nil {
+		return nil, false
+	}
+	v := reflect.ValueOf(value)
+	if v.IsZero() {
+		return nil, false
+	}
+
+	switch value := value.(type) {
+	case types.Command:
+		return value.Name(), true
+	case types.Bindable:
+		return value.Name(), true
+	case color.NRGBA:
+		return fmt.Sprintf("#%02X%02X%02X%02X", value.R, value.G, value.B, value.A), true
+	case string, bool, map[string]any:
+		return value, true
+	case fmt.Stringer:
+		// Constants, e.g. gio constants (see GioConstantFromMap)
+		if v.CanInt() || v.CanUint() {
+			return GioConstantName(value), true
+		}
+	}
+
+	switch {
+	case v.CanInt():
+		return v.Int(), true
+	case v.CanUint():
+		return v.Uint(), true
+	case v.Kind() == reflect.Float32:
+		return float32(v.Float()), true
+	case v.CanFloat():
+		return v.Float(), true
+	case v.Kind() == reflect.String:
+		return v.String(), true
+	}
+
+	if s.options.FunctionName == nil {
+		return nil, false
+	}
+	name, ok := s.options.FunctionName(value)
+	return name, ok
+}
+
+// importedType returns the type name with the prefix of its namespace, if it
+// is imported (see SerializeOptions.Imports).
+func (s serializer) importedType(typeName string) string {
+	dot := strings.LastIndex(typeName, ".")
+	if dot < 0 {
+		return typeName
+	}
+	for prefix, namespace := range s.options.Imports {
+		if namespace == typeName[:dot] {
+			return prefix + typeName[dot:]
+		}
+	}
+	return typeName
+}
+
+// moveBindings is the reverse of moveExpressions: binding expressions and
+// translated messages are written as the value of the property that binds to
+// their binding property (see Property.BindsTo). Text of such a property
+// starting with @ is escaped, so it is not read as a translated message.
+func moveBindings(schema []Property, values map[string]any) {
+	for _, prop := range schema {
+		if prop.Binding == "" {
+			continue
+		}
+		if text, ok := values[prop.Name].(string); ok {
+			if strings.HasPrefix(text, "@") {
+				values[prop.Name] = "@" + text
+			}
+			continue
+		}
+		binding, ok := values[prop.Binding].(string)
+		if ok && (IsExpression(binding) || IsTranslation(binding)) {
+			values[prop.Name] = binding
+			delete(values, prop.Binding)
+		}
+	}
+}
+
+// templateNode returns the node of an element that was not created, e.g. the
+// item template of a list, with its properties in the order of Serialize.
+func (s serializer) templateNode(template map[string]any) *yaml.Node {
+	typeName, _ := template["type"].(string)
+	schema, _ := s.registry.Schema(typeName)
+
+	node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	for _, name := range propertyOrder(schema, template) {
+		var valueNode *yaml.Node
+		switch value := template[name].(type) {
+		case string:
+			if name == "type" {
+				value = s.importedType(value)
+			}
+			valueNode = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+		case map[string]any:
+			valueNode = s.templateNode(value)
+		case []any:
+			valueNode = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+			for _, item := range value {
+				itemNode := new(yaml.Node)
+				if m, ok := item.(map[string]any); ok {
+					itemNode = s.templateNode(m)
+				} else if err := itemNode.Encode(item); err != nil {
+					continue
+				}
+				valueNode.Content = append(valueNode.Content, itemNode)
+			}
+		default:
+			valueNode = new(yaml.Node)
+			if err := valueNode.Encode(value); err != nil {
+				continue
+			}
+		}
+		node.Content = append(node.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name}, valueNode)
+	}
+	return node
+}
+
+// converterOf returns the converter of a converted binding (see
+// types.ConvertedBinding).
+func converterOf(b types.Bindable) (any, bool) {
+	method := reflect.ValueOf(b).MethodByName("Converter")
+	if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 1 {
+		return nil, false
+	}
+	return method.Call(nil)[0].Interface(), true
+}
+
+// propertyOrder returns the names of the properties in the order they are
+// written: the type, id and weight, the properties of the schema, the
+// conditions and the other properties sorted by name.
+func propertyOrder(schema []Property, properties map[string]any) []string {
+	names := make([]string, 0, len(properties))
+	seen := make(map[string]bool, len(properties))
+	for _, props := range [][]Property{commonProperties[:3], schema, commonProperties[3:]} {
+		for _, p := range props {
+			if _, ok := properties[p.Name]; ok && !seen[p.Name] {
+				names = append(names, p.Name)
+				seen[p.Name] = true
+			}
+		}
+	}
+	var rest []string
+	for name := range properties {
+		if !seen[name] {
+			rest = append(rest, name)
+		}
+	}
+	sort.Strings(rest)
+	return append(names, rest...)
+}
+
+// SameFunction returns whether a and b are the same function or value, e.g.
+// to implement SerializeOptions.FunctionName.
+func SameFunction(a any, b any) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if !va.IsValid() || !vb.IsValid() || va.Type() != vb.Type() {
+		return false
+	}
+	switch va.Kind() {
+	case reflect.Func, reflect.Pointer, reflect.Map, reflect.Chan, reflect.UnsafePointer:
+		return va.Pointer() == vb.Pointer()
+	}
+	return va.Comparable() && va.Equal(vb)
+}
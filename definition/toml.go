@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: MIT
+
+package definition
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// decodeTOML decodes a TOML definition. The TOML decoder does not report the
+// positions of values, so errors in a TOML definition have no line and column.
+//
+//	type = "layout.Flex"
+//	axis = "Vertical"
+//
+//	[[children]]
+//	type = "widget.Label"
+//	text = "Hello"
+func decodeTOML(data []byte) (*yaml.Node, error) {
+	var doc map[string]any
+	md, err := toml.Decode(string(data), &doc)
+	if err != nil {
+		return nil, err
+	}
+	if len(doc) == 0 {
+		return nil, nil
+	}
+
+	// Keep the keys of the tables in the order of the file
+	order := make(map[string]int)
+	for i, key := range md.Keys() {
+		if _, ok := order[key.String()]; !ok {
+			order[key.String()] = i
+		}
+	}
+	return tomlNode(doc, nil, order), nil
+}
+
+// tomlNode converts a decoded TOML value at key to a node.
+func tomlNode(value any, key toml.Key, order map[string]int) *yaml.Node {
+	switch value := value.(type) {
+	case map[string]any:
+		node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		keys := make([]string, 0, len(value))
+		for k := range value {
+			keys = append(keys, k)
+		}
+		position := func(k string) int {
+			if i, ok := order[append(key[:len(key):len(key)], k).String()]; ok {
+				return i
+			}
+			return len(order)
+		}
+		sort.SliceStable(keys, func(i, j int) bool {
+			if pi, pj := position(keys[i]), position(keys[j]); pi != pj {
+				return pi < pj
+			}
+			return keys[i] < keys[j]
+		})
+		for _, k := range keys {
+			node.Content = append(node.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: k},
+				tomlNode(value[k], append(key[:len(key):len(key)], k), order))
+		}
+		return node
+	case []map[string]any:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for _, item := range value {
+			node.Content = append(node.Content, tomlNode(item, key, order))
+		}
+		return node
+	case []any:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for _, item := range value {
+			node.Content = append(node.Content, tomlNode(item, key, order))
+		}
+		return node
+	case string:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+	case int64:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int",
+			Value: strconv.FormatInt(value, 10)}
+	case float64:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!float",
+			Value: strconv.FormatFloat(value, 'g', -1, 64)}
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool",
+			Value: strconv.FormatBool(value)}
+	default:
+		// Dates and times
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: fmt.Sprint(value)}
+	}
+}
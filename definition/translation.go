@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: MIT
+
+package definition
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/mheremans/goui/i18n"
+	"github.com/mheremans/goui/types"
+)
+
+// Localized is implemented by windows that translate the strings of
+// definitions, see goui.Window.Localizer.
+type Localized interface {
+	Localizer() *i18n.Localizer
+}
+
+// IsTranslation returns whether a property value is a translated message.
+//
+// A translated message is either a string referencing the message key with an
+// "@" prefix (e.g. `"@start"`), or a mapping with the message key as `tr` and
+// the arguments interpolated in the message as `args`:
+//
+//	text:
+//	  tr: minutesLeft
+//	  args:
+//	    count: Minutes		# binding reference, selects the plural form
+//	    name: .Name			# field of the item in an item template
+//	    max: 10				# literal value
+//
+// String arguments reference bindings, other arguments are literal values. Use
+// "@@" for a string starting with a literal "@". The translations follow the
+// locale of the window (see goui.Window.Locale).
+func IsTranslation(value any) bool {
+	switch value := value.(type) {
+	case string:
+		return strings.HasPrefix(value, "@") && !strings.HasPrefix(value, "@@")
+	case map[string]any:
+		_, ok := value["tr"]
+		return ok
+	}
+	return false
+}
+
+// unescapeTranslation returns the string value of a property that can hold a
+// translation, with a leading "@@" replaced by "@".
+func unescapeTranslation(value string) string {
+	if strings.HasPrefix(value, "@@") {
+		return value[1:]
+	}
+	return value
+}
+
+// translation is a parsed translated message, see IsTranslation.
+type translation struct {
+	key  string
+	args map[string]any
+}
+
+func parseTranslation(value any) (t translation, err error) {
+	switch value := value.(type) {
+	case string:
+		t.key = value[1:]
+	case map[string]any:
+		for name, v := range value {
+			switch name {
+			case "tr":
+				key, ok := v.(string)
+				if !ok {
+					return t, fmt.Errorf("tr must be a message key")
+				}
+				t.key = key
+			case "args":
+				args, ok := v.(map[string]any)
+				if !ok {
+					return t, fmt.Errorf("args must be a mapping of arguments")
+				}
+				t.args = args
+			default:
+				return t, fmt.Errorf("unknown translation property %q", name)
+			}
+		}
+	}
+	if t.key == "" {
+		return t, fmt.Errorf("missing message key")
+	}
+	return t, nil
+}
+
+// localizer returns the localizer of the window of ctx. Without one, messages
+// translate to their key.
+func localizer(ctx types.Context) *i18n.Localizer {
+	if ctx != nil {
+		if l, ok := ctx.Window().(Localized); ok && l.Localizer() != nil {
+			return l.Localizer()
+		}
+	}
+	return i18n.NewLocalizer(nil, nil)
+}
+
+// resolveArgs resolves the arguments of the translated message t: string
+// arguments are replaced by the bindings they reference.
+func resolveArgs(ctx types.Context, t translation) (map[string]any, error) {
+	args := make(map[string]any, len(t.args))
+	for name, arg := range t.args {
+		ref, ok := arg.(string)
+		if !ok {
+			args[name] = arg
+			continue
+		}
+		bnd := findBinding(ctx, ref)
+		if bnd == nil {
+			return nil, fmt.Errorf("no binding %q", ref)
+		}
+		args[name] = bnd
+	}
+	return args, nil
+}
+
+// translationBinding creates the binding holding a translated message (see
+// IsTranslation).
+func translationBinding[B any](ctx types.Context, value any) (res B, err error) {
+	t, err := parseTranslation(value)
+	if err != nil {
+		return
+	}
+	args, err := resolveArgs(ctx, t)
+	if err != nil {
+		return
+	}
+
+	b := localizer(ctx).Binding(t.key, args)
+	res, ok := any(b).(B)
+	if !ok {
+		err = fmt.Errorf("a translation can not be bound as %s", reflect.TypeFor[B]())
+	}
+	return
+}
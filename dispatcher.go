@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: MIT
+
+package goui
+
+import (
+	"sync"
+)
+
+// dispatcher queues functions from any goroutine so they can be executed on
+// the window's event loop goroutine.
+type dispatcher struct {
+	mu         sync.Mutex
+	queue      []func()
+	invalidate func() // Wakes up the event loop, nil until the loop runs
+}
+
+func newDispatcher() *dispatcher {
+	return &dispatcher{}
+}
+
+// start marks the event loop as running on the calling goroutine. From now on
+// every queued function wakes up the event loop through the given invalidate
+// function.
+func (d *dispatcher) start(invalidate func()) {
+	d.mu.Lock()
+	d.invalidate = invalidate
+	pending := len(d.queue) > 0
+	d.mu.Unlock()
+
+	if pending {
+		invalidate()
+	}
+}
+
+// push adds a function to the queue. It is safe to call from any goroutine.
+func (d *dispatcher) push(fn func()) {
+	d.mu.Lock()
+	d.queue = append(d.queue, fn)
+	invalidate := d.invalidate
+	d.mu.Unlock()
+
+	if invalidate != nil {
+		invalidate()
+	}
+}
+
+// drain executes all queued functions in the order they were queued.
+//
+// Functions queued while draining are executed as part of the same drain, so
+// a chain of binding updates settles within a single frame.
+func (d *dispatcher) drain() {
+	for {
+		d.mu.Lock()
+		queue := d.queue
+		d.queue = nil
+		d.mu.Unlock()
+
+		if len(queue) == 0 {
+			return
+		}
+		for _, fn := range queue {
+			fn()
+		}
+	}
+}
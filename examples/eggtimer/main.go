@@ -11,6 +11,7 @@ import (
 	"gioui.org/app"
 	"github.com/mheremans/goui"
 	"github.com/mheremans/goui/examples/eggtimer/views"
+	"github.com/mheremans/goui/i18n"
 	"github.com/mheremans/goui/types"
 )
 
@@ -20,6 +21,11 @@ var window *goui.Window
 func main() {
 	window = goui.NewWindow("Egg Timer")
 	window.SetSize(types.NewWindowSize(400, 600))
+	translations, err := i18n.LoadBundle(views.Translations, "i18n", "en")
+	if err != nil {
+		log.Fatal(err)
+	}
+	window.SetTranslations(translations)
 	window.OnClose = func() {
 		fmt.Println("Window Closed")
 	}
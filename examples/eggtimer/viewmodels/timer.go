@@ -13,9 +13,10 @@ import (
 
 type Timer struct {
 	goui.ViewModel
-	progress      *types.Binding[float32]
-	boiling       *types.Binding[bool]
-	timeRemaining *types.Binding[string]
+	progress      *types.Binding[float32]        `goui:"Progress"`
+	boiling       *types.Binding[bool]           `goui:"Boiling"`
+	timeRemaining *types.Binding[string]         `goui:"Time Remaining"`
+	startLabel    *types.ComputedBinding[string] `goui:"Start Label"`
 
 	tickerStopChan chan struct{}
 	tickerDuration time.Duration
@@ -24,15 +25,21 @@ type Timer struct {
 
 func NewTimer() *Timer {
 	t := &Timer{
-		progress:      types.NewBinding("Progress", float32(0)),
-		boiling:       types.NewBinding("Boiling", false),
-		timeRemaining: types.NewBinding("Time Remaining", ""),
+		boiling: types.NewBinding("Boiling", false),
 
 		tickerStopChan: make(chan struct{}),
 		tickerDuration: time.Millisecond * 40,
 		boilDuration:   time.Minute * 5,
 	}
-	t.RegisterBindings(t.progress, t.boiling, t.timeRemaining)
+	t.startLabel = types.NewComputedBinding("Start Label", func() string {
+		if t.boiling.Get() {
+			return "Stop"
+		}
+		return "Start"
+	}, t.boiling)
+	if err := goui.AutoRegister(t); err != nil {
+		panic(err)
+	}
 	return t
 }
 
@@ -49,6 +56,7 @@ func (t *Timer) Initialize() (err error) {
 func (t *Timer) Destroy() (err error) {
 	t.tickerStopChan <- struct{}{}
 	close(t.tickerStopChan)
+	t.startLabel.Detach()
 
 	return t.ViewModel.Destroy()
 }
@@ -65,6 +73,10 @@ func (t Timer) TimeRemaining() *types.Binding[string] {
 	return t.timeRemaining
 }
 
+func (t Timer) StartLabel() *types.ComputedBinding[string] {
+	return t.startLabel
+}
+
 func (t *Timer) ToggleBoiling() {
 	t.boiling.Set(!t.boiling.Get())
 	if !t.boiling.Get() {
@@ -90,9 +102,11 @@ func (t *Timer) BindingChanged(binding types.Bindable) {
 }
 
 func (t *Timer) reset() {
-	t.boiling.Set(false)
-	t.progress.Set(0)
-	t.timeRemaining.Set("")
+	types.Batch(func() {
+		t.boiling.Set(false)
+		t.progress.Set(0)
+		t.timeRemaining.Set("")
+	})
 }
 
 func (t *Timer) tickRoutine() {
@@ -4,5 +4,10 @@ package views
 
 import "embed"
 
+//go:generate go run github.com/mheremans/goui/cmd/goui gen -view TimerView def/timerview.def.yml
+
 //go:embed def/*.def.yml
 var Definitions embed.FS
+
+//go:embed i18n/*
+var Translations embed.FS
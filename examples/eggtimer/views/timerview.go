@@ -7,6 +7,7 @@ import (
 	"image"
 	"image/color"
 	"math"
+	"os"
 
 	"gioui.org/f32"
 	giolayout "gioui.org/layout"
@@ -16,25 +17,23 @@ import (
 	"github.com/mheremans/goui"
 	"github.com/mheremans/goui/examples/eggtimer/viewmodels"
 	"github.com/mheremans/goui/types"
-	"github.com/mheremans/goui/widget"
 )
 
 type TimerView struct {
 	*goui.View
-
-	input       *widget.Input
-	progressBar *widget.ProgressBar
-	button      *widget.Button
+	TimerViewElements
 }
 
 func NewTimerView() *TimerView {
 	v := new(TimerView)
-	v.View = goui.ConfigureView(
-		v, viewmodels.NewTimer(),
-		goui.NewViewScreen(Definitions, "def/timerview.def.yml"),
-	)
-	v.ExportFunction("drawEgg", v.drawEgg)
-	v.ExportFunction("onButtonStartClicked", v.onButtonStartClicked)
+	screen := goui.NewViewScreen(Definitions, "def/timerview.def.yml")
+	// Set GOUI_DEV_DIR to the views directory to reload the definition when
+	// it changes on disk
+	if dir := os.Getenv("GOUI_DEV_DIR"); dir != "" {
+		screen.EnableHotReload(dir)
+	}
+	v.View = goui.ConfigureView(v, viewmodels.NewTimer(), screen)
+	ExportTimerViewFunctions(v.View, v)
 	return v
 }
 
@@ -44,39 +43,35 @@ func (v *TimerView) Initialize(ctx types.Context) (err error) {
 		return
 	}
 
-	v.FindBinding("Boiling").Watch(v)
-	v.FindBinding("Time Remaining").Watch(v)
-	v.input, _ = goui.GetElementById[*widget.Input](v.View, "timeInput")
-	v.progressBar, _ = goui.GetElementById[*widget.ProgressBar](v.View, "progressBar")
-	v.button, _ = goui.GetElementById[*widget.Button](v.View, "startButton")
+	v.FindBinding(TimerViewBindingTimeRemaining).Watch(v)
+	if err = v.FindElements(v.View); err != nil {
+		err = fmt.Errorf("failed to initialize view: %w", err)
+	}
 
 	return
 }
 
-func (v *TimerView) onButtonStartClicked(ctx types.Context, button types.UIElement) {
-	v.ViewModel().(*viewmodels.Timer).ToggleBoiling()
+func (v *TimerView) DefinitionReloaded(ctx types.Context) {
+	if err := v.FindElements(v.View); err != nil {
+		fmt.Printf("Reloaded definition: %v\n", err)
+	}
+}
+
+func (v *TimerView) ToggleBoiling() types.Command {
+	return goui.NewCommand("toggleBoiling", func(ctx types.Context) {
+		v.ViewModel().(*viewmodels.Timer).ToggleBoiling()
+	})
 }
 
 func (s *TimerView) BindingChanged(binding types.Bindable) {
 	switch binding.Name() {
-	case "Boiling":
-		binding := binding.(*types.Binding[bool])
-		s.UpdateButtonLabel(binding.Get())
-	case "Time Remaining":
+	case TimerViewBindingTimeRemaining:
 		fmt.Printf("Time Remaining: %s\n", binding.(*types.Binding[string]).Get())
 	}
 }
 
-func (s *TimerView) UpdateButtonLabel(boiling bool) {
-	if boiling {
-		s.button.SetLabel("Stop")
-	} else {
-		s.button.SetLabel("Start")
-	}
-}
-
-func (v *TimerView) drawEgg(gtx giolayout.Context, graphic types.UIElement) image.Point {
-	progress, ok := v.FindBinding("Progress").(*types.Binding[float32])
+func (v *TimerView) DrawEgg(gtx giolayout.Context, graphic types.UIElement) image.Point {
+	progress, ok := v.FindBinding(TimerViewBindingProgress).(*types.Binding[float32])
 	if !ok {
 		return image.Point{}
 	}
@@ -0,0 +1,58 @@
+// Code generated by goui gen from def/timerview.def.yml. DO NOT EDIT.
+
+package views
+
+import (
+	"fmt"
+	"image"
+
+	giolayout "gioui.org/layout"
+	"github.com/mheremans/goui"
+	"github.com/mheremans/goui/types"
+	"github.com/mheremans/goui/widget"
+)
+
+// TimerViewElements are the elements with an id in def/timerview.def.yml.
+type TimerViewElements struct {
+	TimeInput   *widget.Input
+	ProgressBar *widget.ProgressBar
+	StartButton *widget.Button
+}
+
+// FindElements looks up the elements in the definition of v. It returns an
+// error when an element is missing, e.g. when the definition failed to load.
+func (e *TimerViewElements) FindElements(v *goui.View) error {
+	var ok bool
+	if e.TimeInput, ok = goui.GetElementById[*widget.Input](v, "timeInput"); !ok {
+		return fmt.Errorf("no element %q of type %s", "timeInput", "*widget.Input")
+	}
+	if e.ProgressBar, ok = goui.GetElementById[*widget.ProgressBar](v, "progressBar"); !ok {
+		return fmt.Errorf("no element %q of type %s", "progressBar", "*widget.ProgressBar")
+	}
+	if e.StartButton, ok = goui.GetElementById[*widget.Button](v, "startButton"); !ok {
+		return fmt.Errorf("no element %q of type %s", "startButton", "*widget.Button")
+	}
+	return nil
+}
+
+// Names of the bindings referenced by def/timerview.def.yml.
+const (
+	TimerViewBindingTimeRemaining = "Time Remaining"
+	TimerViewBindingBoiling       = "Boiling"
+	TimerViewBindingProgress      = "Progress"
+	TimerViewBindingStartLabel    = "Start Label"
+)
+
+// TimerViewFunctions are the functions and commands referenced by
+// def/timerview.def.yml.
+type TimerViewFunctions interface {
+	DrawEgg(giolayout.Context, types.UIElement) image.Point
+	ToggleBoiling() types.Command
+}
+
+// ExportTimerViewFunctions exports the functions of fns under their names in
+// def/timerview.def.yml.
+func ExportTimerViewFunctions(v *goui.View, fns TimerViewFunctions) {
+	v.ExportFunction("drawEgg", fns.DrawEgg)
+	v.ExportFunction("toggleBoiling", fns.ToggleBoiling())
+}
@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: MIT
+
+package gen
+
+import (
+	"flag"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/mheremans/goui/definition"
+)
+
+// Run runs the gen command with the command line arguments args (without the
+// command name):
+//
+//	goui gen [-o file] [-package name] [-view name] [-strict] definition...
+//
+// It generates a Go file for every definition, named after the definition,
+// e.g. timerview_gen.go for def/timerview.def.yml, in the current directory.
+// It is meant to be run by go generate, e.g.
+//
+//	//go:generate go run github.com/mheremans/goui/cmd/goui gen def/timerview.def.yml
+//
+// Only the elements registered in the default registry are known. An
+// application with its own elements runs gen from its own command, importing
+// the packages registering them.
+func Run(args []string, stdout io.Writer, stderr io.Writer) error {
+	flags := flag.NewFlagSet("gen", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	output := flags.String("o", "", "output `file`, only with a single definition")
+	pkg := flags.String("package", "", "package `name` of the generated code")
+	view := flags.String("view", "", "`name` of the view, only with a single definition")
+	strict := flags.Bool("strict", false, "reject properties that are not in the schema")
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "usage: goui gen [flags] definition...\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	files := flags.Args()
+	switch {
+	case len(files) == 0:
+		flags.Usage()
+		return fmt.Errorf("no definition")
+	case len(files) > 1 && (*output != "" || *view != ""):
+		return fmt.Errorf("-o and -view require a single definition")
+	}
+
+	for _, file := range files {
+		out := *output
+		if out == "" {
+			out = baseName(file) + "_gen.go"
+		}
+		options := Options{
+			Package: *pkg,
+			View:    *view,
+			Source:  filepath.ToSlash(file),
+		}
+		if options.Package == "" {
+			options.Package = packageOf(filepath.Dir(out))
+		}
+		if options.View == "" {
+			options.View = identifier(baseName(file))
+		}
+
+		doc, err := parseFile(file, definition.Options{Strict: *strict})
+		if err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+		src, err := Generate(doc, options)
+		if err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+		if err = os.WriteFile(out, src, 0o644); err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "%s: generated %s\n", file, out)
+	}
+	return nil
+}
+
+// parseFile parses the definition file. The files it includes are looked up
+// relative to the current directory, or the directory of file when it is
+// outside of it.
+func parseFile(file string, options definition.Options) (*definition.Document, error) {
+	var filesystem fs.FS
+	name := path.Clean(filepath.ToSlash(file))
+	if fs.ValidPath(name) {
+		filesystem = os.DirFS(".")
+	} else {
+		filesystem = os.DirFS(filepath.Dir(file))
+		name = filepath.Base(file)
+	}
+	return definition.Parse(nil, filesystem, name, options)
+}
+
+// baseName returns the name of file without its directory and extensions,
+// e.g. "timerview" for "def/timerview.def.yml".
+func baseName(file string) string {
+	name := filepath.Base(file)
+	name, _, _ = strings.Cut(name, ".")
+	return name
+}
+
+// packageOf returns the package of the Go files in dir, $GOPACKAGE as set by
+// go generate, or the name of dir when there are none.
+func packageOf(dir string) string {
+	if pkg := os.Getenv("GOPACKAGE"); pkg != "" {
+		return pkg
+	}
+	matches, _ := filepath.Glob(filepath.Join(dir, "*.go"))
+	for _, match := range matches {
+		if strings.HasSuffix(match, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(token.NewFileSet(), match, nil, parser.PackageClauseOnly)
+		if err == nil {
+			return f.Name.Name
+		}
+	}
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	return packageName(filepath.Base(dir))
+}
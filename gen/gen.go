@@ -0,0 +1,374 @@
+// SPDX-License-Identifier: MIT
+
+// Package gen generates typed Go code for view definitions, see Generate. It
+// is the implementation of the `goui gen` command.
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"reflect"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/mheremans/goui/definition"
+)
+
+// Options configures the generated code.
+type Options struct {
+	Package string // Package of the generated file
+	View    string // Name of the view, the prefix of the generated names
+	Source  string // Name of the definition in the generated comments
+}
+
+// Generate generates the Go code for the definition doc. For a view named
+// TimerView it contains:
+//
+//   - TimerViewElements, a struct with a typed field for every element with an
+//     id, and its FindElements method looking the elements up in the view.
+//   - Constants with the names of the referenced bindings, e.g.
+//     TimerViewBindingProgress for the binding "Progress".
+//   - TimerViewFunctions, an interface with a method for every referenced
+//     function (with the signature the property expects) and command, and
+//     ExportTimerViewFunctions exporting them under their names in the
+//     definition. A view missing a function, or with a function of the wrong
+//     type, then fails to compile.
+func Generate(doc *definition.Document, options Options) ([]byte, error) {
+	if options.Package == "" {
+		return nil, fmt.Errorf("no package name")
+	}
+	if options.View == "" || !isIdentifier(options.View) {
+		return nil, fmt.Errorf("invalid view name %q", options.View)
+	}
+	if options.Source == "" && len(doc.Files()) > 0 {
+		options.Source = doc.Files()[0]
+	}
+
+	g := &generator{
+		options: options,
+		imports: newImportSet(),
+	}
+	if err := g.elements(doc); err != nil {
+		return nil, err
+	}
+	g.bindings(doc)
+	if err := g.functions(doc); err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by goui gen from %s. DO NOT EDIT.\n\n", options.Source)
+	fmt.Fprintf(&out, "package %s\n\n", options.Package)
+	g.imports.write(&out)
+	out.Write(g.body.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated code: %w", err)
+	}
+	return src, nil
+}
+
+type generator struct {
+	options Options
+	imports *importSet
+	body    bytes.Buffer
+}
+
+func (g *generator) printf(format string, args ...any) {
+	fmt.Fprintf(&g.body, format, args...)
+}
+
+// elements generates the struct with the elements with an id.
+func (g *generator) elements(doc *definition.Document) error {
+	type field struct {
+		name string
+		id   string
+		typ  string
+	}
+	var fields []field
+	for _, elem := range doc.Elements() {
+		// Elements of an item template are created for every item
+		if elem.ID == "" || elem.Template {
+			continue
+		}
+		if elem.GoType == nil {
+			return fmt.Errorf("%s: no such element: %s", elem.ID, elem.Type)
+		}
+		name := identifier(elem.ID)
+		for _, f := range fields {
+			if f.name == name {
+				return fmt.Errorf("elements %q and %q have the same field name %s",
+					f.id, elem.ID, name)
+			}
+		}
+		fields = append(fields, field{name: name, id: elem.ID, typ: g.typeExpr(elem.GoType)})
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+
+	goui := g.imports.qualifier("github.com/mheremans/goui")
+	fmtPkg := g.imports.qualifier("fmt")
+	name := g.options.View + "Elements"
+
+	g.printf("// %s are the elements with an id in %s.\n", name, g.options.Source)
+	g.printf("type %s struct {\n", name)
+	for _, f := range fields {
+		g.printf("%s %s\n", f.name, f.typ)
+	}
+	g.printf("}\n\n")
+
+	g.printf("// FindElements looks up the elements in the definition of v. It returns an\n")
+	g.printf("// error when an element is missing, e.g. when the definition failed to load.\n")
+	g.printf("func (e *%s) FindElements(v *%s.View) error {\n", name, goui)
+	g.printf("var ok bool\n")
+	for _, f := range fields {
+		g.printf("if e.%s, ok = %s.GetElementById[%s](v, %q); !ok {\n", f.name, goui, f.typ, f.id)
+		g.printf("return %s.Errorf(\"no element %%q of type %%s\", %q, %q)\n", fmtPkg, f.id, f.typ)
+		g.printf("}\n")
+	}
+	g.printf("return nil\n}\n\n")
+	return nil
+}
+
+// bindings generates the constants with the names of the bindings.
+func (g *generator) bindings(doc *definition.Document) {
+	var names []string
+	for _, ref := range doc.References() {
+		if ref.Kind == definition.BindingReference && !slices.Contains(names, ref.Name) {
+			names = append(names, ref.Name)
+		}
+	}
+	if len(names) == 0 {
+		return
+	}
+
+	g.printf("// Names of the bindings referenced by %s.\n", g.options.Source)
+	g.printf("const (\n")
+	seen := make(map[string]bool)
+	for _, name := range names {
+		constName := g.options.View + "Binding" + identifier(name)
+		if seen[constName] {
+			continue
+		}
+		seen[constName] = true
+		g.printf("%s = %q\n", constName, name)
+	}
+	g.printf(")\n\n")
+}
+
+// functions generates the interface with the functions and the function
+// exporting them.
+func (g *generator) functions(doc *definition.Document) error {
+	type function struct {
+		name   string
+		method string
+		typ    reflect.Type
+	}
+	var functions []function
+	for _, ref := range doc.References() {
+		if ref.Kind != definition.FunctionReference || ref.Type == nil {
+			continue
+		}
+		i := slices.IndexFunc(functions, func(f function) bool { return f.name == ref.Name })
+		if i >= 0 {
+			if functions[i].typ != ref.Type {
+				return fmt.Errorf("function %q is used as %s and as %s",
+					ref.Name, functions[i].typ, ref.Type)
+			}
+			continue
+		}
+		method := identifier(ref.Name)
+		for _, f := range functions {
+			if f.method == method {
+				return fmt.Errorf("functions %q and %q have the same method name %s",
+					f.name, ref.Name, method)
+			}
+		}
+		functions = append(functions, function{name: ref.Name, method: method, typ: ref.Type})
+	}
+	if len(functions) == 0 {
+		return nil
+	}
+
+	goui := g.imports.qualifier("github.com/mheremans/goui")
+	name := g.options.View + "Functions"
+
+	g.printf("// %s are the functions and commands referenced by\n", name)
+	g.printf("// %s.\n", g.options.Source)
+	g.printf("type %s interface {\n", name)
+	for _, f := range functions {
+		if f.typ.Kind() == reflect.Func {
+			g.printf("%s%s\n", f.method, g.signature(f.typ))
+		} else {
+			g.printf("%s() %s\n", f.method, g.typeExpr(f.typ))
+		}
+	}
+	g.printf("}\n\n")
+
+	g.printf("// Export%s exports the functions of fns under their names in\n", name)
+	g.printf("// %s.\n", g.options.Source)
+	g.printf("func Export%s(v *%s.View, fns %s) {\n", name, goui, name)
+	for _, f := range functions {
+		switch {
+		case f.typ.Kind() != reflect.Func:
+			g.printf("v.ExportFunction(%q, fns.%s())\n", f.name, f.method)
+		case f.typ.Name() != "":
+			g.printf("v.ExportFunction(%q, %s(fns.%s))\n", f.name, g.typeExpr(f.typ), f.method)
+		default:
+			g.printf("v.ExportFunction(%q, fns.%s)\n", f.name, f.method)
+		}
+	}
+	g.printf("}\n")
+	return nil
+}
+
+// signature returns the parameters and results of the function type t.
+func (g *generator) signature(t reflect.Type) string {
+	params := make([]string, t.NumIn())
+	for i := range params {
+		if t.IsVariadic() && i == len(params)-1 {
+			params[i] = "..." + g.typeExpr(t.In(i).Elem())
+			continue
+		}
+		params[i] = g.typeExpr(t.In(i))
+	}
+	results := make([]string, t.NumOut())
+	for i := range results {
+		results[i] = g.typeExpr(t.Out(i))
+	}
+
+	s := "(" + strings.Join(params, ", ") + ")"
+	switch len(results) {
+	case 0:
+		return s
+	case 1:
+		return s + " " + results[0]
+	default:
+		return s + " (" + strings.Join(results, ", ") + ")"
+	}
+}
+
+// typeExpr returns the Go expression of the type t, qualified with the
+// imported packages.
+func (g *generator) typeExpr(t reflect.Type) string {
+	if t.Name() != "" {
+		if t.PkgPath() == "" {
+			return t.Name()
+		}
+		name, args, generic := strings.Cut(t.Name(), "[")
+		name = g.imports.qualifier(t.PkgPath()) + "." + name
+		if generic {
+			name += "[" + g.typeArgs(strings.TrimSuffix(args, "]")) + "]"
+		}
+		return name
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return "*" + g.typeExpr(t.Elem())
+	case reflect.Slice:
+		return "[]" + g.typeExpr(t.Elem())
+	case reflect.Array:
+		return fmt.Sprintf("[%d]%s", t.Len(), g.typeExpr(t.Elem()))
+	case reflect.Map:
+		return "map[" + g.typeExpr(t.Key()) + "]" + g.typeExpr(t.Elem())
+	case reflect.Chan:
+		return t.ChanDir().String() + " " + g.typeExpr(t.Elem())
+	case reflect.Func:
+		return "func" + g.signature(t)
+	case reflect.Interface:
+		if t.NumMethod() == 0 {
+			return "any"
+		}
+	}
+	return t.String()
+}
+
+// typeArgs returns the type arguments of a generic type, as reflect names
+// them, e.g. "github.com/mheremans/goui/types.Item,string".
+func (g *generator) typeArgs(args string) string {
+	var res []string
+	depth, start := 0, 0
+	for i := 0; i <= len(args); i++ {
+		if i < len(args) {
+			switch args[i] {
+			case '[':
+				depth++
+				continue
+			case ']':
+				depth--
+				continue
+			case ',':
+				if depth > 0 {
+					continue
+				}
+			default:
+				continue
+			}
+		}
+		res = append(res, g.typeArg(args[start:i]))
+		start = i + 1
+	}
+	return strings.Join(res, ", ")
+}
+
+func (g *generator) typeArg(arg string) string {
+	prefix := ""
+	for {
+		switch {
+		case strings.HasPrefix(arg, "*"):
+			prefix += "*"
+			arg = arg[1:]
+			continue
+		case strings.HasPrefix(arg, "[]"):
+			prefix += "[]"
+			arg = arg[2:]
+			continue
+		}
+		break
+	}
+	name, _, _ := strings.Cut(arg, "[")
+	dot := strings.LastIndex(name, ".")
+	if dot < 0 {
+		return prefix + arg
+	}
+	return prefix + g.imports.qualifier(arg[:dot]) + arg[dot:]
+}
+
+// identifier returns the exported Go identifier for a name in a definition,
+// e.g. "TimeInput" for "timeInput" or "TimeRemaining" for "Time Remaining".
+func identifier(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if b.Len() == 0 && unicode.IsDigit(r) {
+			b.WriteRune('N')
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	if b.Len() == 0 {
+		return "X"
+	}
+	return b.String()
+}
+
+func isIdentifier(name string) bool {
+	for i, r := range name {
+		if !unicode.IsLetter(r) && r != '_' && (i == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return name != ""
+}
@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: MIT
+
+package gen
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// importSet holds the packages imported by the generated code, with the name
+// they are referred to by.
+type importSet struct {
+	names map[string]string // Name by package path
+	used  map[string]bool
+}
+
+func newImportSet() *importSet {
+	return &importSet{
+		names: make(map[string]string),
+		used:  make(map[string]bool),
+	}
+}
+
+// qualifier imports the package with path and returns its name. The gio
+// packages are named with a gio prefix (e.g. giolayout), like in goui itself.
+func (s *importSet) qualifier(pkgPath string) string {
+	if name, ok := s.names[pkgPath]; ok {
+		return name
+	}
+
+	base := packageName(pkgPath)
+	if strings.HasPrefix(pkgPath, "gioui.org/") {
+		base = "gio" + base
+	}
+	name := base
+	for i := 2; s.used[name]; i++ {
+		name = base + strconv.Itoa(i)
+	}
+	s.names[pkgPath] = name
+	s.used[name] = true
+	return name
+}
+
+// write writes the import declaration, the standard library first.
+func (s *importSet) write(w io.Writer) {
+	if len(s.names) == 0 {
+		return
+	}
+	var std, other []string
+	for pkgPath := range s.names {
+		if strings.Contains(strings.Split(pkgPath, "/")[0], ".") {
+			other = append(other, pkgPath)
+		} else {
+			std = append(std, pkgPath)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(other)
+
+	fmt.Fprintf(w, "import (\n")
+	for i, group := range [][]string{std, other} {
+		if i > 0 && len(std) > 0 && len(other) > 0 {
+			fmt.Fprintln(w)
+		}
+		for _, pkgPath := range group {
+			if name := s.names[pkgPath]; name != packageName(pkgPath) {
+				fmt.Fprintf(w, "\t%s %q\n", name, pkgPath)
+			} else {
+				fmt.Fprintf(w, "\t%q\n", pkgPath)
+			}
+		}
+	}
+	fmt.Fprintf(w, ")\n\n")
+}
+
+// packageName returns the name of the package with path, assuming it is the
+// last element of the path without a major version suffix.
+func packageName(pkgPath string) string {
+	name := path.Base(pkgPath)
+	if len(name) > 1 && name[0] == 'v' && isDigits(name[1:]) {
+		name = path.Base(path.Dir(pkgPath))
+	}
+	name, _, _ = strings.Cut(name, ".")
+	return strings.ReplaceAll(name, "-", "")
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
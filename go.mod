@@ -4,6 +4,7 @@ go 1.22.3
 
 require (
 	gioui.org v0.6.0
+	github.com/BurntSushi/toml v1.6.0
 	github.com/google/uuid v1.6.0
 	golang.org/x/exp v0.0.0-20221012211006-4de253d81b95
 	golang.org/x/exp/shiny v0.0.0-20220827204233-334a2380cb91
@@ -0,0 +1,334 @@
+// SPDX-License-Identifier: MIT
+
+// Package gouitest runs views and widgets headless, without an OS window or a
+// GPU, so they can be tested with go test.
+//
+// A Harness initializes a view with a headless Window, draws frames into an
+// op.Ops, injects pointer and key events at the elements by their id and
+// reports where the elements were drawn:
+//
+//	h, err := gouitest.New(views.NewTimerView(), image.Pt(400, 600))
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	defer h.Close()
+//
+//	if err := h.Type("timeInput", "3"); err != nil {
+//		t.Fatal(err)
+//	}
+//	if err := h.Click("startButton"); err != nil {
+//		t.Fatal(err)
+//	}
+//	bounds, _ := h.Bounds("progressBar")
+//
+// Snapshot writes the element tree as drawn, with the bounds, texts and
+// values of the elements, as text. CheckGolden compares it with a golden
+// file, so layout and text changes show up as diffs of the golden files.
+package gouitest
+
+import (
+	"fmt"
+	"image"
+	"time"
+
+	"gioui.org/io/input"
+	giolayout "gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/unit"
+	"github.com/mheremans/goui/definition"
+	"github.com/mheremans/goui/types"
+)
+
+// FrameInterval is the time between two frames, see Harness.Frame.
+const FrameInterval = time.Second / 60
+
+// Harness runs a view headless.
+type Harness struct {
+	window *Window
+	view   types.View
+	ctx    *context
+
+	router input.Router
+	ops    op.Ops
+	size   image.Point
+	metric unit.Metric
+	start  time.Time
+	now    time.Time
+
+	pointerDelay time.Duration // Added to the time of pointer events, see ClickAt
+
+	drawn  []drawnElement // Elements drawn in the last frame, in draw order
+	closed bool
+}
+
+// drawnElement is an element drawn in a frame, with its bounds in the window.
+type drawnElement struct {
+	element   types.UIElement
+	parent    types.UIElement // Element that drew it, nil for the root
+	bounds    image.Rectangle
+	hasBounds bool // False for elements without a size, e.g. hidden elements
+}
+
+// rooted is implemented by views with an element tree, like goui.View.
+type rooted interface {
+	Root() types.UIElement
+}
+
+// New initializes view in a headless window of the given size (in pixels,
+// with one pixel per dp) and draws the first frame. A goui.View loads its
+// definition while initializing.
+func New(view types.View, size image.Point) (*Harness, error) {
+	h := &Harness{
+		window: NewWindow(),
+		view:   view,
+		size:   size,
+		metric: unit.Metric{PxPerDp: 1, PxPerSp: 1},
+		start:  time.Unix(0, 0).UTC(),
+	}
+	h.now = h.start
+	h.ctx = &context{window: h.window}
+	if err := view.Initialize(h.ctx); err != nil {
+		return nil, fmt.Errorf("failed to initialize view: %w", err)
+	}
+	h.Frame()
+	return h, nil
+}
+
+// NewElement runs the element created by build as the only element of a
+// view, e.g. to test a widget without a definition.
+func NewElement(build func(ctx types.Context) types.UIElement, size image.Point) (*Harness, error) {
+	return New(&elementView{build: build}, size)
+}
+
+// Close destroys the view.
+func (h *Harness) Close() error {
+	if h.closed {
+		return nil
+	}
+	h.closed = true
+	return h.view.Destroy(h.ctx)
+}
+
+// Window returns the headless window the view runs in.
+func (h *Harness) Window() *Window {
+	return h.window
+}
+
+// Context returns the context the view runs in, e.g. to execute a command.
+func (h *Harness) Context() types.Context {
+	return h.ctx
+}
+
+// Ops returns the operations of the last frame.
+func (h *Harness) Ops() *op.Ops {
+	return &h.ops
+}
+
+// Size returns the size of the window.
+func (h *Harness) Size() image.Point {
+	return h.size
+}
+
+// SetSize resizes the window and draws a frame.
+func (h *Harness) SetSize(size image.Point) {
+	h.size = size
+	h.Frame()
+}
+
+// Now returns the time of the last frame. The first frame is at the Unix
+// epoch, each frame is FrameInterval later than the previous one.
+func (h *Harness) Now() time.Time {
+	return h.now
+}
+
+// Frame runs a frame like the event loop of goui.Window: the functions
+// queued with Window.Dispatch (e.g. binding notifications) are executed, the
+// view handles the events injected since the previous frame, the functions
+// queued meanwhile are executed and the view is drawn.
+func (h *Harness) Frame() {
+	h.now = h.now.Add(FrameInterval)
+	h.window.drain()
+	h.window.mu.Lock()
+	h.window.invalidated = false
+	h.window.mu.Unlock()
+	h.window.resetTrace()
+
+	h.ops.Reset()
+	h.ctx.gtx = giolayout.Context{
+		Constraints: giolayout.Exact(h.size),
+		Metric:      h.metric,
+		Now:         h.now,
+		Source:      h.router.Source(),
+		Ops:         &h.ops,
+	}
+	h.view.HandleEvents(h.ctx)
+	h.window.drain()
+	h.view.DrawView(h.ctx)
+	h.router.Frame(&h.ops)
+	h.collectBounds()
+}
+
+// Settle runs frames until the window is no longer invalidated, at most n.
+// It returns whether the view settled, e.g. after a binding changed.
+func (h *Harness) Settle(n int) bool {
+	for i := 0; i < n; i++ {
+		if !h.window.Invalidated() {
+			return true
+		}
+		h.Frame()
+	}
+	return !h.window.Invalidated()
+}
+
+// collectBounds looks up the bounds of the elements drawn in the last frame,
+// from the semantic tree of the frame (see Window.TraceElement).
+func (h *Harness) collectBounds() {
+	traced := h.window.traced
+	h.drawn = h.drawn[:0]
+	for _, t := range traced {
+		d := drawnElement{element: t.element}
+		if t.parent >= 0 {
+			d.parent = traced[t.parent].element
+		}
+		h.drawn = append(h.drawn, d)
+	}
+	for _, node := range h.router.AppendSemantics(nil) {
+		index, ok := h.window.tracedIndex(node.Desc.Description)
+		if !ok || h.drawn[index].hasBounds {
+			continue
+		}
+		h.drawn[index].bounds = node.Desc.Bounds
+		h.drawn[index].hasBounds = true
+	}
+}
+
+// Root returns the root element of the view, nil for views without an
+// element tree.
+func (h *Harness) Root() types.UIElement {
+	if r, ok := h.view.(rooted); ok {
+		return r.Root()
+	}
+	return nil
+}
+
+// Element returns the element with id. Elements of the element tree of the
+// view (see definition.Parent) are found whether or not they were drawn,
+// other elements (e.g. the items of a list) when they were drawn in the last
+// frame.
+func (h *Harness) Element(id string) (types.UIElement, bool) {
+	var found types.UIElement
+	walk(h.Root(), func(elem types.UIElement) bool {
+		if elem.ID() == id {
+			found = elem
+			return false
+		}
+		return true
+	})
+	if found != nil {
+		return found, true
+	}
+	for _, d := range h.drawn {
+		if d.element.ID() == id {
+			return d.element, true
+		}
+	}
+	return nil, false
+}
+
+// Bounds returns the position and size of the element with id in the window,
+// as drawn in the last frame. ok is false when the element was not drawn,
+// e.g. because it is hidden.
+func (h *Harness) Bounds(id string) (bounds image.Rectangle, ok bool) {
+	elem, ok := h.Element(id)
+	if !ok {
+		return
+	}
+	return h.ElementBounds(elem)
+}
+
+// ElementBounds is like Bounds, for the element elem.
+func (h *Harness) ElementBounds(elem types.UIElement) (bounds image.Rectangle, ok bool) {
+	for _, d := range h.drawn {
+		if d.element == elem && d.hasBounds {
+			return d.bounds, true
+		}
+	}
+	return
+}
+
+// walk calls fn for elem and its descendants, depth first, until fn returns
+// false. It returns false when it was stopped.
+func walk(elem types.UIElement, fn func(types.UIElement) bool) bool {
+	if elem == nil {
+		return true
+	}
+	if !fn(elem) {
+		return false
+	}
+	if parent, ok := elem.(definition.Parent); ok {
+		for _, child := range parent.Children() {
+			if !walk(child.Element, fn) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// elementView is a view with a single element, see NewElement.
+type elementView struct {
+	build func(ctx types.Context) types.UIElement
+	root  types.UIElement
+	wnd   types.Window
+}
+
+func (v *elementView) Initialize(ctx types.Context) error {
+	ctx.SetView(v)
+	v.wnd = ctx.Window()
+	v.root = v.build(ctx)
+	if v.root == nil {
+		return fmt.Errorf("no element")
+	}
+	return nil
+}
+
+func (v *elementView) Destroy(types.Context) error {
+	if disposable, ok := v.root.(types.Disposable); ok {
+		disposable.Dispose()
+	}
+	return nil
+}
+
+func (v *elementView) Root() types.UIElement {
+	return v.root
+}
+
+func (v *elementView) HandleEvents(ctx types.Context) {
+	types.HandleElementEvents(ctx, v.root)
+}
+
+func (v *elementView) Draw(gtx giolayout.Context) giolayout.Dimensions {
+	return types.DrawElement(gtx, v.root)
+}
+
+func (v *elementView) DrawView(ctx types.Context) giolayout.Dimensions {
+	return v.Draw(ctx.Gtx())
+}
+
+func (v *elementView) FindFunction(string) any {
+	return nil
+}
+
+func (v *elementView) FindBinding(string) types.Bindable {
+	return nil
+}
+
+func (v *elementView) ID() string {
+	return "gouitest"
+}
+
+func (v *elementView) SetID(string) {}
+
+func (v *elementView) Wnd() types.Window {
+	return v.wnd
+}
@@ -0,0 +1,185 @@
+// SPDX-License-Identifier: MIT
+
+package gouitest
+
+import (
+	"image"
+	"testing"
+
+	giolayout "gioui.org/layout"
+	"github.com/mheremans/goui/layout"
+	"github.com/mheremans/goui/types"
+	"github.com/mheremans/goui/widget"
+)
+
+// testCommand counts how many times it is executed.
+type testCommand struct {
+	executed   int
+	canExecute *types.Binding[bool]
+}
+
+func newTestCommand() *testCommand {
+	return &testCommand{canExecute: types.NewBinding("CanExecute", true)}
+}
+
+func (c *testCommand) Name() string {
+	return "test"
+}
+
+func (c *testCommand) Execute(types.Context) {
+	c.executed++
+}
+
+func (c *testCommand) CanExecute() types.ValueBinding[bool] {
+	return c.canExecute
+}
+
+type person struct {
+	Name string
+}
+
+func newForm(t *testing.T, command types.Command, name *types.Binding[string]) *Harness {
+	t.Helper()
+	h, err := NewElement(func(ctx types.Context) types.UIElement {
+		button := widget.NewButton(ctx, "Start", "start")
+		button.SetCommand(command)
+		input := widget.NewInput(ctx, "Name", "name")
+		input.Bind(name)
+		return layout.NewFlex(ctx, giolayout.Vertical, giolayout.SpaceEnd,
+			giolayout.Start, button, input)
+	}, image.Pt(300, 400))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { h.Close() })
+	return h
+}
+
+func TestHarnessBounds(t *testing.T) {
+	h := newForm(t, newTestCommand(), types.NewBinding("Name", ""))
+
+	button, ok := h.Bounds("start")
+	if !ok {
+		t.Fatal("button has no bounds")
+	}
+	input, ok := h.Bounds("name")
+	if !ok {
+		t.Fatal("input has no bounds")
+	}
+	if button.Empty() || input.Empty() {
+		t.Fatalf("empty bounds: button %v, input %v", button, input)
+	}
+	if button.Min != image.Pt(0, 0) {
+		t.Errorf("button starts at %v, want (0,0)", button.Min)
+	}
+	if input.Min.Y != button.Max.Y {
+		t.Errorf("input starts at y=%d, want it below the button at y=%d",
+			input.Min.Y, button.Max.Y)
+	}
+	if window := (image.Rectangle{Max: h.Size()}); !button.In(window) || !input.In(window) {
+		t.Errorf("bounds outside the window: button %v, input %v", button, input)
+	}
+	if _, ok := h.Bounds("missing"); ok {
+		t.Error("unknown element has bounds")
+	}
+}
+
+func TestHarnessClick(t *testing.T) {
+	command := newTestCommand()
+	h := newForm(t, command, types.NewBinding("Name", ""))
+
+	if err := h.Click("start"); err != nil {
+		t.Fatal(err)
+	}
+	if command.executed != 1 {
+		t.Fatalf("command executed %d times, want 1", command.executed)
+	}
+
+	command.canExecute.Set(false)
+	if !h.Settle(10) {
+		t.Fatal("view did not settle")
+	}
+	if err := h.Click("start"); err != nil {
+		t.Fatal(err)
+	}
+	if command.executed != 1 {
+		t.Errorf("disabled command executed %d times, want 1", command.executed)
+	}
+
+	if err := h.Click("missing"); err == nil {
+		t.Error("clicking an unknown element did not fail")
+	}
+}
+
+func TestHarnessType(t *testing.T) {
+	name := types.NewBinding("Name", "")
+	h := newForm(t, newTestCommand(), name)
+
+	if err := h.Type("name", "Ada"); err != nil {
+		t.Fatal(err)
+	}
+	if got := name.Get(); got != "Ada" {
+		t.Fatalf("binding is %q, want %q", got, "Ada")
+	}
+	if err := h.Type("name", " Lovelace"); err != nil {
+		t.Fatal(err)
+	}
+	if got := name.Get(); got != "Ada Lovelace" {
+		t.Errorf("binding is %q, want %q", got, "Ada Lovelace")
+	}
+
+	name.Set("Grace")
+	if !h.Settle(10) {
+		t.Fatal("view did not settle")
+	}
+	elem, _ := h.Element("name")
+	if got := elem.(*widget.Input).Text(); got != "Grace" {
+		t.Errorf("input shows %q, want %q", got, "Grace")
+	}
+}
+
+// TestHarnessListItemRemoved removes items from the item event handler while
+// the list is laid out. The change is delivered to the list right away, like
+// the event loop does when it runs queued functions meanwhile, which used to
+// index the rows out of range.
+func TestHarnessListItemRemoved(t *testing.T) {
+	people := types.NewListBinding("People", []person{
+		{"Ada"}, {"Grace"}, {"Edsger"}, {"Barbara"},
+	})
+	removed := false
+	handler := func(ctx types.Context, index int, list types.BindableList) {
+		if index == 0 && !removed {
+			removed = true
+			people.RemoveAt(1, 3)
+			ctx.Window().(*Window).drain()
+		}
+	}
+
+	var list *widget.List
+	h, err := NewElement(func(ctx types.Context) types.UIElement {
+		list = widget.NewList(ctx, giolayout.Vertical, giolayout.Start, handler, nil, "people")
+		list.SetTemplate(map[string]any{"type": "widget.Label", "binding": ".Name"})
+		list.Bind(people)
+		return list
+	}, image.Pt(300, 400))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	if !removed {
+		t.Fatal("item event handler was not called")
+	}
+	if !h.Settle(10) {
+		t.Fatal("view did not settle")
+	}
+	if err := list.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if got := people.Size(); got != 1 {
+		t.Fatalf("list has %d items, want 1", got)
+	}
+	if _, ok := h.Bounds("people"); !ok {
+		t.Error("list has no bounds")
+	}
+}
@@ -0,0 +1,141 @@
+// SPDX-License-Identifier: MIT
+
+package gouitest
+
+import (
+	"fmt"
+	"image"
+	"time"
+	"unicode/utf8"
+
+	"gioui.org/f32"
+	"gioui.org/io/event"
+	"gioui.org/io/key"
+	"gioui.org/io/pointer"
+)
+
+// Queue injects events into the window, without running a frame. They are
+// handled in the next frame, by the elements that were drawn at their
+// position in the last frame (pointer events) or that have the focus (key
+// events).
+func (h *Harness) Queue(events ...event.Event) {
+	h.router.Queue(events...)
+}
+
+// Pointer injects pointer events and runs a frame. The time of the events is
+// set to the time of the last frame unless it is set, mouse presses press the
+// primary button unless they press another one.
+func (h *Harness) Pointer(events ...pointer.Event) {
+	for _, e := range events {
+		if e.Time == 0 {
+			e.Time = h.now.Sub(h.start) + h.pointerDelay
+		}
+		if e.Source == pointer.Mouse && e.Kind == pointer.Press && e.Buttons == 0 {
+			e.Buttons = pointer.ButtonPrimary
+		}
+		h.router.Queue(e)
+	}
+	h.Frame()
+}
+
+// Center returns the center of the element with id, as drawn in the last
+// frame.
+func (h *Harness) Center(id string) (f32.Point, error) {
+	bounds, ok := h.Bounds(id)
+	if !ok {
+		return f32.Point{}, fmt.Errorf("element %q was not drawn", id)
+	}
+	center := bounds.Min.Add(bounds.Max).Div(2)
+	return f32.Pt(float32(center.X), float32(center.Y)), nil
+}
+
+// ClickAt clicks the primary mouse button at pos, in window coordinates, and
+// runs a frame. Clicks are never taken for a double click.
+func (h *Harness) ClickAt(pos image.Point) {
+	// Frames are only FrameInterval apart, so delay the pointer events
+	h.pointerDelay += time.Second
+	p := f32.Pt(float32(pos.X), float32(pos.Y))
+	h.Pointer(
+		pointer.Event{Kind: pointer.Move, Source: pointer.Mouse, Position: p},
+		pointer.Event{Kind: pointer.Press, Source: pointer.Mouse, Position: p},
+		pointer.Event{Kind: pointer.Release, Source: pointer.Mouse, Position: p},
+	)
+}
+
+// Click clicks the center of the element with id and runs a frame.
+func (h *Harness) Click(id string) error {
+	p, err := h.Center(id)
+	if err != nil {
+		return err
+	}
+	h.ClickAt(image.Pt(int(p.X), int(p.Y)))
+	return nil
+}
+
+// Hover moves the mouse to the center of the element with id and runs a
+// frame.
+func (h *Harness) Hover(id string) error {
+	p, err := h.Center(id)
+	if err != nil {
+		return err
+	}
+	h.Pointer(pointer.Event{Kind: pointer.Move, Source: pointer.Mouse, Position: p})
+	return nil
+}
+
+// Scroll scrolls by dist (in pixels) with the mouse at the center of the
+// element with id and runs a frame.
+func (h *Harness) Scroll(id string, dist image.Point) error {
+	p, err := h.Center(id)
+	if err != nil {
+		return err
+	}
+	h.Pointer(
+		pointer.Event{Kind: pointer.Move, Source: pointer.Mouse, Position: p},
+		pointer.Event{
+			Kind:     pointer.Scroll,
+			Source:   pointer.Mouse,
+			Position: p,
+			Scroll:   f32.Pt(float32(dist.X), float32(dist.Y)),
+		},
+	)
+	return nil
+}
+
+// Focus gives the focus to the element with id by clicking it, like a user
+// would, e.g. before typing in an input.
+func (h *Harness) Focus(id string) error {
+	return h.Click(id)
+}
+
+// Key presses and releases the key name, with the modifiers, and runs a
+// frame. The key is handled by the element that has the focus.
+func (h *Harness) Key(name key.Name, modifiers ...key.Modifiers) {
+	var mods key.Modifiers
+	for _, m := range modifiers {
+		mods |= m
+	}
+	h.router.Queue(
+		key.Event{Name: name, Modifiers: mods, State: key.Press},
+		key.Event{Name: name, Modifiers: mods, State: key.Release},
+	)
+	h.Frame()
+}
+
+// Type focuses the element with id and types text at its caret, like an
+// input method does, and runs a frame. The text replaces the selection, the
+// caret is moved after it.
+func (h *Harness) Type(id string, text string) error {
+	if err := h.Focus(id); err != nil {
+		return err
+	}
+	r := h.router.EditorState().Selection.Range
+	start := min(r.Start, r.End)
+	caret := start + utf8.RuneCountInString(text)
+	h.router.Queue(
+		key.EditEvent{Range: r, Text: text},
+		key.SelectionEvent{Start: caret, End: caret},
+	)
+	h.Frame()
+	return nil
+}
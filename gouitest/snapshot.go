@@ -0,0 +1,174 @@
+// SPDX-License-Identifier: MIT
+
+package gouitest
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mheremans/goui/definition"
+	"github.com/mheremans/goui/types"
+)
+
+var update = flag.Bool("gouitest.update", false, "update the golden files of CheckGolden")
+
+// texter is implemented by elements showing a text, e.g. widget.Label and
+// widget.Input.
+type texter interface {
+	Text() string
+}
+
+// labeler is implemented by elements with a label, e.g. widget.Button.
+type labeler interface {
+	Label() string
+}
+
+// Snapshot returns a textual snapshot of the element tree of the view, as
+// drawn in the last frame. Each element is written on a line, indented by
+// its depth, with its type (see definition.TypeName), id, bounds in the
+// window, text (or label), value and conditions, e.g.
+//
+//	layout.Flex id=root bounds=(0,0)-(300,400)
+//	  widget.Button id=startButton bounds=(0,0)-(300,37) text="Start" enabled=false
+//	  widget.Slider id=timeSlider visible=false value=0.5
+//
+// The children of an element are the elements of the tree (see
+// definition.Parent), followed by the other elements it drew, e.g. the items
+// of a list. Generated ids, bounds of elements that were not drawn and
+// conditions that are met are left out, so the snapshot is stable and can be
+// compared with a golden file, see CheckGolden.
+func (h *Harness) Snapshot() string {
+	var b strings.Builder
+	h.snapshot(&b, h.Root(), 0)
+	return b.String()
+}
+
+func (h *Harness) snapshot(b *strings.Builder, elem types.UIElement, depth int) {
+	if elem == nil {
+		return
+	}
+	b.WriteString(strings.Repeat("  ", depth))
+	typeName, ok := definition.TypeName(elem)
+	if !ok {
+		typeName = definition.DerivedTypeName(elem)
+	}
+	b.WriteString(typeName)
+	if id := elem.ID(); id != "" && uuid.Validate(id) != nil {
+		fmt.Fprintf(b, " id=%s", id)
+	}
+	if bounds, ok := h.ElementBounds(elem); ok {
+		fmt.Fprintf(b, " bounds=%v", bounds)
+	}
+	switch e := elem.(type) {
+	case texter:
+		fmt.Fprintf(b, " text=%s", strconv.Quote(e.Text()))
+	case labeler:
+		fmt.Fprintf(b, " text=%s", strconv.Quote(e.Label()))
+	}
+	if value, ok := valueOf(elem); ok {
+		fmt.Fprintf(b, " value=%v", value)
+	}
+	if c, ok := elem.(types.Conditional); ok {
+		if !c.Visible() {
+			b.WriteString(" visible=false")
+		}
+		if !c.Enabled() {
+			b.WriteString(" enabled=false")
+		}
+	}
+	b.WriteByte('\n')
+
+	for _, child := range h.children(elem) {
+		h.snapshot(b, child, depth+1)
+	}
+}
+
+// children returns the children of elem in the element tree, followed by
+// the other elements it drew in the last frame.
+func (h *Harness) children(elem types.UIElement) []types.UIElement {
+	var children []types.UIElement
+	if parent, ok := elem.(definition.Parent); ok {
+		for _, child := range parent.Children() {
+			if child.Element != nil {
+				children = append(children, child.Element)
+			}
+		}
+	}
+	for _, d := range h.drawn {
+		if d.parent == elem && !containsElement(children, d.element) {
+			children = append(children, d.element)
+		}
+	}
+	return children
+}
+
+func containsElement(elems []types.UIElement, elem types.UIElement) bool {
+	for _, e := range elems {
+		if e == elem {
+			return true
+		}
+	}
+	return false
+}
+
+// valueOf returns the result of the Value method of elem, e.g. the value of
+// a widget.Slider. ok is false when elem has no such method.
+func valueOf(elem types.UIElement) (value any, ok bool) {
+	m := reflect.ValueOf(elem).MethodByName("Value")
+	if !m.IsValid() || m.Type().NumIn() != 0 || m.Type().NumOut() != 1 {
+		return nil, false
+	}
+	return m.Call(nil)[0].Interface(), true
+}
+
+// CheckGolden compares the snapshot of the view (see Snapshot) with the
+// golden file, e.g. testdata/timerview.golden, and reports the first
+// difference as an error of t. With the -gouitest.update flag of go test,
+// the golden file is written instead:
+//
+//	go test ./... -args -gouitest.update
+func (h *Harness) CheckGolden(t testing.TB, file string) {
+	t.Helper()
+	got := h.Snapshot()
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("failed to read golden file (update it with -gouitest.update): %v", err)
+	}
+	want := string(data)
+	if got == want {
+		return
+	}
+	gotLines := strings.Split(got, "\n")
+	wantLines := strings.Split(want, "\n")
+	for i := 0; i < len(gotLines) || i < len(wantLines); i++ {
+		var g, w string
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if g != w {
+			t.Errorf("snapshot differs from %s at line %d:\n got: %s\nwant: %s\n\nsnapshot:\n%s",
+				file, i+1, g, w, got)
+			return
+		}
+	}
+}
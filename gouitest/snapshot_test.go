@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: MIT
+
+package gouitest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mheremans/goui/types"
+)
+
+func TestCheckGolden(t *testing.T) {
+	h := newForm(t, newTestCommand(), types.NewBinding("Name", "Ada"))
+	h.CheckGolden(t, "testdata/form.golden")
+}
+
+func TestCheckGoldenUpdate(t *testing.T) {
+	h := newForm(t, newTestCommand(), types.NewBinding("Name", "Ada"))
+	file := filepath.Join(t.TempDir(), "testdata", "form.golden")
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(file, []byte("outdated\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func(old bool) { *update = old }(*update)
+	*update = true
+	h.CheckGolden(t, file)
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(data), h.Snapshot(); got != want {
+		t.Errorf("golden file is\n%s\nwant\n%s", got, want)
+	}
+
+	*update = false
+	h.CheckGolden(t, file)
+}
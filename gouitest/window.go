@@ -0,0 +1,190 @@
+// SPDX-License-Identifier: MIT
+
+package gouitest
+
+import (
+	"embed"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gioui.org/font/gofont"
+	"gioui.org/io/semantic"
+	giolayout "gioui.org/layout"
+	"gioui.org/op/clip"
+	"gioui.org/text"
+	"gioui.org/widget/material"
+	"github.com/mheremans/goui/i18n"
+	"github.com/mheremans/goui/types"
+)
+
+// tracePrefix prefixes the semantic descriptions the window adds to find the
+// bounds of the drawn elements.
+const tracePrefix = "gouitest:"
+
+// Window is a headless types.Window. It draws with the Go fonts only, so
+// layouts do not depend on the fonts of the system.
+type Window struct {
+	theme     *material.Theme
+	localizer *i18n.Localizer
+
+	mu          sync.Mutex
+	queue       []func() // Functions queued with Dispatch
+	invalidated bool
+
+	traced  []tracedElement // Elements drawn in the current frame, in draw order
+	drawing int             // Index in traced of the element being drawn, -1 when none
+}
+
+// tracedElement is an element drawn in a frame.
+type tracedElement struct {
+	element types.UIElement
+	parent  int // Index in traced of the element that drew it, -1 for the root
+}
+
+// NewWindow creates a headless window, with the locale i18n.DefaultLocale.
+func NewWindow() *Window {
+	theme := material.NewTheme()
+	theme.Shaper = text.NewShaper(text.NoSystemFonts(), text.WithCollection(gofont.Collection()))
+	w := &Window{
+		theme:   theme,
+		drawing: -1,
+		localizer: i18n.NewLocalizer(nil,
+			types.NewBinding("Locale", i18n.DefaultLocale)),
+	}
+	return w
+}
+
+// Theme returns the material theme of the window.
+func (w *Window) Theme() *material.Theme {
+	return w.theme
+}
+
+// Invalidate marks the window as needing a new frame, see Invalidated.
+func (w *Window) Invalidate() {
+	w.mu.Lock()
+	w.invalidated = true
+	w.mu.Unlock()
+}
+
+// Invalidated returns whether the window was invalidated since the last
+// frame.
+func (w *Window) Invalidated() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.invalidated
+}
+
+// Dispatch queues fn to be executed in the next frame, like the event loop of
+// goui.Window (see Harness.Frame). It is safe to call from any goroutine.
+func (w *Window) Dispatch(fn func()) {
+	w.mu.Lock()
+	w.queue = append(w.queue, fn)
+	w.invalidated = true
+	w.mu.Unlock()
+}
+
+// drain executes the queued functions, including the functions they queue.
+func (w *Window) drain() {
+	for {
+		w.mu.Lock()
+		queue := w.queue
+		w.queue = nil
+		w.mu.Unlock()
+
+		if len(queue) == 0 {
+			return
+		}
+		for _, fn := range queue {
+			fn()
+		}
+	}
+}
+
+// SetTranslations sets the message catalogs used to translate the strings of
+// the definitions, see goui.Window.SetTranslations.
+func (w *Window) SetTranslations(bundle *i18n.Bundle) {
+	w.localizer = i18n.NewLocalizer(bundle, w.localizer.Locale())
+}
+
+// Locale returns the binding holding the current locale of the window.
+func (w *Window) Locale() *types.Binding[string] {
+	return w.localizer.Locale()
+}
+
+// Localizer returns the localizer translating the strings of the definitions.
+func (w *Window) Localizer() *i18n.Localizer {
+	return w.localizer
+}
+
+// TraceElement draws elem and records it as drawn (see types.DrawTracer). It
+// adds a clip area with the size of elem, with a semantic description
+// identifying elem, so the bounds of elem in the window can be looked up
+// after the frame.
+func (w *Window) TraceElement(
+	gtx giolayout.Context,
+	elem types.UIElement,
+	draw func(gtx giolayout.Context) giolayout.Dimensions,
+) giolayout.Dimensions {
+	index := len(w.traced)
+	w.traced = append(w.traced, tracedElement{element: elem, parent: w.drawing})
+	w.drawing = index
+	dims := draw(gtx)
+	w.drawing = w.traced[index].parent
+
+	defer clip.Rect{Max: dims.Size}.Push(gtx.Ops).Pop()
+	semantic.DescriptionOp(tracePrefix + strconv.Itoa(index)).Add(gtx.Ops)
+	return dims
+}
+
+// resetTrace forgets the elements drawn in the previous frame.
+func (w *Window) resetTrace() {
+	w.traced = w.traced[:0]
+	w.drawing = -1
+}
+
+// tracedIndex returns the index in traced of the element a semantic
+// description added by TraceElement identifies.
+func (w *Window) tracedIndex(desc string) (int, bool) {
+	s, ok := strings.CutPrefix(desc, tracePrefix)
+	if !ok {
+		return 0, false
+	}
+	index, err := strconv.Atoi(s)
+	if err != nil || index < 0 || index >= len(w.traced) {
+		return 0, false
+	}
+	return index, true
+}
+
+// context is the context the view is initialized and drawn with.
+type context struct {
+	window   *Window
+	view     types.View
+	gtx      giolayout.Context
+	fontsDir *embed.FS
+}
+
+func (c *context) Window() types.Window {
+	return c.window
+}
+
+func (c *context) View() types.View {
+	return c.view
+}
+
+func (c *context) Gtx() giolayout.Context {
+	return c.gtx
+}
+
+func (c *context) FontsDir() *embed.FS {
+	return c.fontsDir
+}
+
+func (c *context) SetFontsDir(fs *embed.FS) {
+	c.fontsDir = fs
+}
+
+func (c *context) SetView(v types.View) {
+	c.view = v
+}
@@ -0,0 +1,156 @@
+// SPDX-License-Identifier: MIT
+
+package goui
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"time"
+
+	giolayout "gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+	"gioui.org/widget/material"
+
+	"github.com/mheremans/goui/definition"
+	"github.com/mheremans/goui/types"
+)
+
+// hotReloadInterval is the interval at which definition files are checked for
+// changes in dev mode.
+const hotReloadInterval = 500 * time.Millisecond
+
+// ReloadHandler is implemented by views that want to be informed when their
+// definition was reloaded (see ViewScreen.EnableHotReload), e.g. to update
+// references to elements obtained with GetElementById.
+type ReloadHandler interface {
+	DefinitionReloaded(types.Context)
+}
+
+// startWatching starts polling the definition file and the files it includes
+// for changes. A change schedules a reload at the next frame.
+func (v *View) startWatching(wnd types.Window) {
+	names := v.watchedFiles()
+	paths := make([]string, 0, len(names))
+	for _, name := range names {
+		paths = append(paths, filepath.Join(v.viewScreen.devDir, name))
+	}
+
+	v.stopWatch = make(chan struct{})
+	go watchFiles(paths, v.stopWatch, func() {
+		wnd.Dispatch(func() {
+			v.reloadPending = true
+		})
+	})
+}
+
+// watchedFiles returns the names of the files of the definition. When the last
+// load failed, these are the files that could be resolved, so fixing any of
+// them triggers a reload.
+func (v *View) watchedFiles() []string {
+	if v.loadErr == nil && v.def != nil {
+		return v.def.Files()
+	}
+	return definition.Files(v.viewScreen.filesystem(), v.viewScreen.screenName,
+		v.viewScreen.options())
+}
+
+func (v *View) stopWatching() {
+	if v.stopWatch != nil {
+		close(v.stopWatch)
+		v.stopWatch = nil
+	}
+}
+
+// reload rebuilds the element tree from the definition file and swaps the
+// root. State is transferred between elements with the same id. When the
+// definition has an error, the old tree is kept and the error is shown on top
+// of it.
+func (v *View) reload(ctx types.Context) {
+	v.reloadPending = false
+	ctx.SetView(v.impl)
+
+	def, err := v.viewScreen.load(ctx)
+	if err != nil {
+		v.loadErr = fmt.Errorf("failed to reload definition: %w", err)
+		// The failed definition may include other files
+		v.stopWatching()
+		v.startWatching(v.Wnd())
+		v.Wnd().Invalidate()
+		return
+	}
+
+	def.TransferState(v.def)
+	if v.def != nil {
+		v.def.Dispose()
+	}
+	v.def = def
+	v.root = def.Root()
+	v.loadErr = nil
+
+	// The definition may include other files now
+	v.stopWatching()
+	v.startWatching(v.Wnd())
+
+	if handler, ok := v.impl.(ReloadHandler); ok {
+		handler.DefinitionReloaded(ctx)
+	}
+	v.Wnd().Invalidate()
+}
+
+// drawLoadError draws the definition error on top of the view.
+func (v *View) drawLoadError(gtx giolayout.Context) giolayout.Dimensions {
+	label := material.Body2(v.Wnd().Theme(), v.loadErr.Error())
+	label.Color = color.NRGBA{R: 255, G: 255, B: 255, A: 255}
+	inset := giolayout.UniformInset(unit.Dp(8))
+
+	// Record the text first, so the background can be sized to fit it
+	macro := op.Record(gtx.Ops)
+	dims := inset.Layout(gtx, label.Layout)
+	call := macro.Stop()
+
+	rect := clip.Rect{Max: image.Pt(gtx.Constraints.Max.X, dims.Size.Y)}
+	paint.FillShape(gtx.Ops, color.NRGBA{R: 211, G: 47, B: 47, A: 230}, rect.Op())
+	call.Add(gtx.Ops)
+	return giolayout.Dimensions{Size: rect.Max}
+}
+
+func watchFiles(paths []string, stop <-chan struct{}, onChange func()) {
+	lastMod := make([]time.Time, len(paths))
+	for i, path := range paths {
+		lastMod[i] = modTime(path)
+	}
+	ticker := time.NewTicker(hotReloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			changed := false
+			for i, path := range paths {
+				if mod := modTime(path); !mod.Equal(lastMod[i]) {
+					lastMod[i] = mod
+					changed = true
+				}
+			}
+			if changed {
+				onChange()
+			}
+		}
+	}
+}
+
+func modTime(path string) time.Time {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
@@ -0,0 +1,200 @@
+// SPDX-License-Identifier: MIT
+
+package i18n
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"reflect"
+	"strings"
+)
+
+// Bundle holds the catalogs of all locales of an application.
+//
+// Messages are looked up in the catalog of the requested locale, then in the
+// catalog of its language (e.g. "nl" for "nl_BE") and finally in the catalogs
+// of the fallback locale. Missing messages translate to their key.
+type Bundle struct {
+	fallback string
+	catalogs map[string]*Catalog
+}
+
+// NewBundle creates an empty bundle with the given fallback locale.
+func NewBundle(fallback string) *Bundle {
+	return &Bundle{
+		fallback: normalizeLocale(fallback),
+		catalogs: make(map[string]*Catalog),
+	}
+}
+
+// LoadBundle creates a bundle with the given fallback locale and loads the
+// catalogs in dir of fsys (see Bundle.Load).
+func LoadBundle(fsys fs.FS, dir string, fallback string) (*Bundle, error) {
+	b := NewBundle(fallback)
+	if err := b.Load(fsys, dir); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (b Bundle) Fallback() string {
+	return b.fallback
+}
+
+// Load loads all JSON (*.json) and gettext (*.po) catalogs in dir of fsys. The
+// locale of a catalog is its file name without extension, e.g. nl_BE.po.
+func (b *Bundle) Load(fsys fs.FS, dir string) error {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		ext := path.Ext(name)
+		var parse func(string, []byte) (*Catalog, error)
+		switch strings.ToLower(ext) {
+		case ".json":
+			parse = ParseJSON
+		case ".po":
+			parse = ParsePO
+		default:
+			continue
+		}
+
+		file := path.Join(dir, name)
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return err
+		}
+		c, err := parse(strings.TrimSuffix(name, ext), data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+		b.Add(c)
+	}
+	return nil
+}
+
+// Add adds a catalog. The messages of a catalog of a locale that was already
+// added are merged into the existing catalog.
+func (b *Bundle) Add(c *Catalog) {
+	existing, ok := b.catalogs[c.locale]
+	if !ok {
+		b.catalogs[c.locale] = c
+		return
+	}
+	for key, forms := range c.messages {
+		existing.messages[key] = forms
+	}
+}
+
+// Catalog returns the catalog of locale.
+func (b Bundle) Catalog(locale string) (*Catalog, bool) {
+	c, ok := b.catalogs[normalizeLocale(locale)]
+	return c, ok
+}
+
+// Locales returns the locales of all catalogs.
+func (b Bundle) Locales() []string {
+	locales := make([]string, 0, len(b.catalogs))
+	for locale := range b.catalogs {
+		locales = append(locales, locale)
+	}
+	return locales
+}
+
+// Has returns whether the message key can be translated for locale, in its
+// own catalog or in a fallback catalog.
+func (b Bundle) Has(locale string, key string) bool {
+	_, ok := b.lookup(locale, key)
+	return ok
+}
+
+// Translate translates the message key for locale.
+//
+// The argument "count" selects the plural form of the message. Arguments are
+// interpolated in the message with {name}, optionally with a fmt verb, e.g.
+// {percent:%.1f}. Use {{ and }} for literal braces.
+func (b Bundle) Translate(locale string, key string, args map[string]any) string {
+	c, ok := b.lookup(locale, key)
+	if !ok {
+		return interpolate(key, args)
+	}
+	count, _ := toInt(args["count"])
+	form, _ := c.Form(key, count)
+	return interpolate(form, args)
+}
+
+// lookup returns the catalog holding the message key for locale.
+func (b Bundle) lookup(locale string, key string) (*Catalog, bool) {
+	for _, l := range fallbacks(normalizeLocale(locale), b.fallback) {
+		c, ok := b.catalogs[l]
+		if !ok {
+			continue
+		}
+		if _, ok := c.messages[key]; ok {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+// interpolate replaces the {name} placeholders in message with the arguments.
+// Unknown placeholders are kept as is.
+func interpolate(message string, args map[string]any) string {
+	if !strings.ContainsAny(message, "{}") {
+		return message
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(message); i++ {
+		ch := message[i]
+		switch {
+		case (ch == '{' || ch == '}') && i+1 < len(message) && message[i+1] == ch:
+			sb.WriteByte(ch)
+			i++
+			continue
+		case ch != '{':
+			sb.WriteByte(ch)
+			continue
+		}
+
+		end := strings.IndexByte(message[i:], '}')
+		if end < 0 {
+			sb.WriteString(message[i:])
+			break
+		}
+		placeholder := message[i+1 : i+end]
+		name, format, formatted := strings.Cut(placeholder, ":")
+		value, ok := args[strings.TrimSpace(name)]
+		switch {
+		case !ok:
+			sb.WriteString(message[i : i+end+1])
+		case formatted:
+			sb.WriteString(fmt.Sprintf(format, value))
+		default:
+			sb.WriteString(fmt.Sprint(value))
+		}
+		i += end
+	}
+	return sb.String()
+}
+
+// toInt converts a numeric argument to an int.
+func toInt(value any) (int, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return int(v.Float()), true
+	}
+	return 0, false
+}
@@ -0,0 +1,278 @@
+// SPDX-License-Identifier: MIT
+
+package i18n
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Catalog holds the messages of one locale.
+//
+// A message has one form, or one form per plural category of the locale
+// (see PluralRule).
+type Catalog struct {
+	locale   string
+	plural   PluralRule
+	messages map[string][]string
+}
+
+// NewCatalog creates an empty catalog for locale, with the default plural
+// rule of its language (see DefaultPluralRule).
+func NewCatalog(locale string) *Catalog {
+	return &Catalog{
+		locale:   normalizeLocale(locale),
+		plural:   DefaultPluralRule(locale),
+		messages: make(map[string][]string),
+	}
+}
+
+func (c Catalog) Locale() string {
+	return c.locale
+}
+
+// SetPluralRule sets the rule selecting the plural form of a message.
+func (c *Catalog) SetPluralRule(rule PluralRule) {
+	c.plural = rule
+}
+
+// Set sets the forms of the message key: a single form, or one form per
+// plural category.
+func (c *Catalog) Set(key string, forms ...string) {
+	c.messages[key] = forms
+}
+
+// Lookup returns the forms of the message key.
+func (c Catalog) Lookup(key string) ([]string, bool) {
+	forms, ok := c.messages[key]
+	return forms, ok
+}
+
+// Keys returns the keys of all messages.
+func (c Catalog) Keys() []string {
+	keys := make([]string, 0, len(c.messages))
+	for key := range c.messages {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Form returns the form of the message key for the count n.
+func (c Catalog) Form(key string, n int) (string, bool) {
+	forms, ok := c.messages[key]
+	if !ok || len(forms) == 0 {
+		return "", false
+	}
+	index := c.plural(n)
+	if index < 0 || index >= len(forms) {
+		index = len(forms) - 1
+	}
+	return forms[index], true
+}
+
+// ParseJSON parses a JSON catalog for locale. The catalog is an object of
+// message keys to a string, or to a list of plural forms. The optional key
+// "@plural" holds the plural formula (see ParsePluralRule).
+//
+//	{
+//	  "@plural": "n != 1",
+//	  "start": "Start",
+//	  "minutesLeft": ["{count} minute left", "{count} minutes left"]
+//	}
+func ParseJSON(locale string, data []byte) (*Catalog, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid catalog: %w", err)
+	}
+
+	c := NewCatalog(locale)
+	for key, value := range raw {
+		if key == "@plural" {
+			var formula string
+			if err := json.Unmarshal(value, &formula); err != nil {
+				return nil, fmt.Errorf("invalid catalog: @plural must be a string")
+			}
+			rule, err := ParsePluralRule(formula)
+			if err != nil {
+				return nil, fmt.Errorf("invalid catalog: %w", err)
+			}
+			c.plural = rule
+			continue
+		}
+
+		var form string
+		if err := json.Unmarshal(value, &form); err == nil {
+			c.Set(key, form)
+			continue
+		}
+		var forms []string
+		if err := json.Unmarshal(value, &forms); err != nil {
+			return nil, fmt.Errorf(
+				"invalid catalog: message %q must be a string or a list of strings", key)
+		}
+		c.Set(key, forms...)
+	}
+	return c, nil
+}
+
+// ParsePO parses a gettext PO catalog for locale. The msgid is the message
+// key. The plural formula is read from the Plural-Forms header. Fuzzy and
+// untranslated messages are skipped.
+func ParsePO(locale string, data []byte) (*Catalog, error) {
+	c := NewCatalog(locale)
+
+	var entry poEntry
+	var field *string // Field continued by a string on the next line
+	lineNo := 0
+
+	flush := func() error {
+		defer func() {
+			entry = poEntry{}
+			field = nil
+		}()
+		if entry.id == "" && !entry.hasPlural {
+			return c.parsePOHeader(entry.forms()[0])
+		}
+		if entry.fuzzy {
+			return nil
+		}
+		forms := entry.forms()
+		for _, form := range forms {
+			if form == "" {
+				return nil
+			}
+		}
+		key := entry.id
+		if entry.context != "" {
+			key = entry.context + "\x04" + entry.id
+		}
+		c.Set(key, forms...)
+		return nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	started := false
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, "#,"):
+			if started {
+				if err := flush(); err != nil {
+					return nil, err
+				}
+				started = false
+			}
+			entry.fuzzy = strings.Contains(line, "fuzzy")
+			continue
+		case strings.HasPrefix(line, "#"):
+			continue
+		case strings.HasPrefix(line, `"`):
+			if field == nil {
+				return nil, fmt.Errorf("invalid catalog: line %d: unexpected string", lineNo)
+			}
+			s, err := strconv.Unquote(line)
+			if err != nil {
+				return nil, fmt.Errorf("invalid catalog: line %d: %w", lineNo, err)
+			}
+			*field += s
+			continue
+		}
+
+		keyword, value, _ := strings.Cut(line, " ")
+		s, err := strconv.Unquote(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid catalog: line %d: %w", lineNo, err)
+		}
+
+		// A msgctxt or msgid after a msgstr starts the next entry
+		if started && (keyword == "msgctxt" || (keyword == "msgid" && entry.hasStr)) {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+		started = true
+
+		switch {
+		case keyword == "msgctxt":
+			entry.context = s
+			field = &entry.context
+		case keyword == "msgid":
+			entry.id = s
+			field = &entry.id
+		case keyword == "msgid_plural":
+			entry.hasPlural = true
+			entry.plural = s
+			field = &entry.plural
+		case keyword == "msgstr":
+			entry.hasStr = true
+			entry.str = append(entry.str, s)
+			field = &entry.str[len(entry.str)-1]
+		case strings.HasPrefix(keyword, "msgstr["):
+			index, err := strconv.Atoi(strings.TrimSuffix(keyword[len("msgstr["):], "]"))
+			if err != nil || index != len(entry.str) {
+				return nil, fmt.Errorf("invalid catalog: line %d: unexpected %s", lineNo, keyword)
+			}
+			entry.hasStr = true
+			entry.str = append(entry.str, s)
+			field = &entry.str[len(entry.str)-1]
+		default:
+			return nil, fmt.Errorf("invalid catalog: line %d: unknown keyword %s", lineNo, keyword)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("invalid catalog: %w", err)
+	}
+	if started {
+		if err := flush(); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+type poEntry struct {
+	context   string
+	id        string
+	plural    string
+	hasPlural bool
+	str       []string
+	hasStr    bool
+	fuzzy     bool
+}
+
+func (e poEntry) forms() []string {
+	if len(e.str) == 0 {
+		return []string{""}
+	}
+	return e.str
+}
+
+// parsePOHeader reads the plural formula from the header of a PO catalog.
+func (c *Catalog) parsePOHeader(header string) error {
+	for _, line := range strings.Split(header, "\n") {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok || !strings.EqualFold(strings.TrimSpace(name), "Plural-Forms") {
+			continue
+		}
+		for _, part := range strings.Split(value, ";") {
+			key, formula, ok := strings.Cut(part, "=")
+			if !ok || strings.TrimSpace(key) != "plural" {
+				continue
+			}
+			rule, err := ParsePluralRule(strings.TrimSpace(formula))
+			if err != nil {
+				return fmt.Errorf("invalid catalog: %w", err)
+			}
+			c.plural = rule
+		}
+	}
+	return nil
+}
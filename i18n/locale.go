@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: MIT
+
+package i18n
+
+import (
+	"os"
+	"slices"
+	"strings"
+)
+
+// DefaultLocale is the locale used when no locale can be detected.
+const DefaultLocale = "en"
+
+// DetectLocale returns the locale of the user, as configured by the LC_ALL,
+// LC_MESSAGES or LANG environment variables, or DefaultLocale.
+func DetectLocale() string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		locale := normalizeLocale(os.Getenv(name))
+		if locale != "" && locale != "C" && locale != "POSIX" {
+			return locale
+		}
+	}
+	return DefaultLocale
+}
+
+// normalizeLocale returns locale in the form language_REGION, e.g. "nl-be" and
+// "nl_BE.UTF-8" become "nl_BE".
+func normalizeLocale(locale string) string {
+	locale, _, _ = strings.Cut(locale, ".")
+	locale, _, _ = strings.Cut(locale, "@")
+	lang, region, ok := strings.Cut(strings.ReplaceAll(locale, "-", "_"), "_")
+	if lang == "C" || lang == "POSIX" {
+		return lang
+	}
+	if !ok {
+		return strings.ToLower(lang)
+	}
+	return strings.ToLower(lang) + "_" + strings.ToUpper(region)
+}
+
+// language returns the language of a normalized locale, e.g. "nl" for
+// "nl_BE".
+func language(locale string) string {
+	lang, _, _ := strings.Cut(locale, "_")
+	return lang
+}
+
+// fallbacks returns the locales to look a message up in for locale: the
+// locale itself, its language and the fallback locale and its language.
+func fallbacks(locale string, fallback string) []string {
+	var locales []string
+	for _, l := range []string{locale, language(locale), fallback, language(fallback)} {
+		if l != "" && !slices.Contains(locales, l) {
+			locales = append(locales, l)
+		}
+	}
+	return locales
+}
@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: MIT
+
+package i18n
+
+import (
+	"github.com/mheremans/goui/types"
+)
+
+// Localizer translates messages of a bundle to the current locale.
+//
+// The current locale is held by a binding. Translations obtained with Binding
+// follow the locale: changing it re-renders all translated strings bound to
+// UI elements.
+type Localizer struct {
+	bundle *Bundle
+	locale *types.Binding[string]
+}
+
+// NewLocalizer creates a localizer for the bundle, following the locale
+// binding. A nil locale binding is replaced by a new binding named "Locale",
+// holding the detected locale (see DetectLocale).
+func NewLocalizer(bundle *Bundle, locale *types.Binding[string]) *Localizer {
+	if bundle == nil {
+		bundle = NewBundle(DefaultLocale)
+	}
+	if locale == nil {
+		locale = types.NewBinding("Locale", DetectLocale())
+	}
+	return &Localizer{bundle: bundle, locale: locale}
+}
+
+func (l Localizer) Bundle() *Bundle {
+	return l.bundle
+}
+
+// Locale returns the binding holding the current locale.
+func (l Localizer) Locale() *types.Binding[string] {
+	return l.locale
+}
+
+// Has returns whether the message key can be translated for the current
+// locale.
+func (l Localizer) Has(key string) bool {
+	return l.bundle.Has(l.locale.Get(), key)
+}
+
+// Translate translates the message key to the current locale (see
+// Bundle.Translate).
+func (l Localizer) Translate(key string, args map[string]any) string {
+	return l.bundle.Translate(l.locale.Get(), key, args)
+}
+
+// Binding returns a binding holding the translation of the message key.
+//
+// Arguments can be plain values or bindings (types.Bindable with a Get
+// method). The translation is updated when the locale or one of the argument
+// bindings changes. The binding only watches its sources while it is watched
+// itself, so it can be dropped without being detached.
+func (l *Localizer) Binding(key string, args map[string]any) *types.ComputedBinding[string] {
+	sources := []types.Bindable{l.locale}
+	for _, arg := range args {
+		if bnd, ok := arg.(types.Bindable); ok {
+			sources = append(sources, bnd)
+		}
+	}
+
+	return types.NewLazyComputedBinding("@"+key, func() string {
+		values := make(map[string]any, len(args))
+		for name, arg := range args {
+			if bnd, ok := arg.(types.Bindable); ok {
+				arg, _ = types.BindingValue(bnd)
+			}
+			values[name] = arg
+		}
+		return l.Translate(key, values)
+	}, sources...)
+}
@@ -0,0 +1,261 @@
+// SPDX-License-Identifier: MIT
+
+package i18n
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PluralRule returns the index of the plural form to use for the count n.
+type PluralRule func(n int) int
+
+// defaultPluralFormulas are the plural formulas of languages with rules other
+// than "n != 1", in gettext syntax. They are used when a catalog does not
+// declare its own formula.
+var defaultPluralFormulas = map[string]string{
+	"ja": "0", "ko": "0", "zh": "0", "vi": "0", "th": "0", "id": "0",
+	"fr": "n > 1", "pt_BR": "n > 1", "tr": "n > 1",
+	"ru": "n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2",
+	"uk": "n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2",
+	"pl": "n==1 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2",
+	"cs": "n==1 ? 0 : n>=2 && n<=4 ? 1 : 2",
+	"sk": "n==1 ? 0 : n>=2 && n<=4 ? 1 : 2",
+}
+
+// DefaultPluralRule returns the plural rule of the language of locale, or the
+// rule "n != 1" (as in English) for unknown languages.
+func DefaultPluralRule(locale string) PluralRule {
+	locale = normalizeLocale(locale)
+	formula, ok := defaultPluralFormulas[locale]
+	if !ok {
+		formula, ok = defaultPluralFormulas[language(locale)]
+	}
+	if !ok {
+		formula = "n != 1"
+	}
+	rule, _ := ParsePluralRule(formula)
+	return rule
+}
+
+// ParsePluralRule parses a plural formula in the C syntax used by the
+// Plural-Forms header of gettext, e.g. "n != 1" or
+// "n==1 ? 0 : n%10>=2 && n%10<=4 ? 1 : 2". Boolean results are 1 for true and
+// 0 for false.
+func ParsePluralRule(formula string) (PluralRule, error) {
+	p := &pluralParser{src: formula}
+	e, err := p.parseTernary()
+	if err == nil {
+		p.skipSpace()
+		if p.pos < len(p.src) {
+			err = p.errorf("unexpected %q", p.src[p.pos:])
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid plural formula %q: %w", formula, err)
+	}
+	return func(n int) int {
+		return e(n)
+	}, nil
+}
+
+type pluralExpr func(n int) int
+
+type pluralParser struct {
+	src string
+	pos int
+}
+
+// pluralLevels are the binary operators, from the lowest to the highest
+// precedence.
+var pluralLevels = [][]string{
+	{"||"},
+	{"&&"},
+	{"==", "!="},
+	{"<=", ">=", "<", ">"},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+func (p *pluralParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("offset %d: %s", p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *pluralParser) parseTernary() (pluralExpr, error) {
+	cond, err := p.parseBinary(0)
+	if err != nil {
+		return nil, err
+	}
+	if !p.consume("?") {
+		return cond, nil
+	}
+	then, err := p.parseTernary()
+	if err != nil {
+		return nil, err
+	}
+	if !p.consume(":") {
+		return nil, p.errorf("missing :")
+	}
+	otherwise, err := p.parseTernary()
+	if err != nil {
+		return nil, err
+	}
+	return func(n int) int {
+		if cond(n) != 0 {
+			return then(n)
+		}
+		return otherwise(n)
+	}, nil
+}
+
+func (p *pluralParser) parseBinary(level int) (pluralExpr, error) {
+	if level == len(pluralLevels) {
+		return p.parseUnary()
+	}
+	l, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.operator(pluralLevels[level])
+		if !ok {
+			return l, nil
+		}
+		r, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		l = pluralBinary(op, l, r)
+	}
+}
+
+func pluralBinary(op string, l, r pluralExpr) pluralExpr {
+	b := func(v bool) int {
+		if v {
+			return 1
+		}
+		return 0
+	}
+	return func(n int) int {
+		a, c := l(n), r(n)
+		switch op {
+		case "||":
+			return b(a != 0 || c != 0)
+		case "&&":
+			return b(a != 0 && c != 0)
+		case "==":
+			return b(a == c)
+		case "!=":
+			return b(a != c)
+		case "<":
+			return b(a < c)
+		case "<=":
+			return b(a <= c)
+		case ">":
+			return b(a > c)
+		case ">=":
+			return b(a >= c)
+		case "+":
+			return a + c
+		case "-":
+			return a - c
+		case "*":
+			return a * c
+		case "/":
+			if c == 0 {
+				return 0
+			}
+			return a / c
+		case "%":
+			if c == 0 {
+				return 0
+			}
+			return a % c
+		}
+		return 0
+	}
+}
+
+func (p *pluralParser) parseUnary() (pluralExpr, error) {
+	if p.consume("!") {
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return func(n int) int {
+			if x(n) == 0 {
+				return 1
+			}
+			return 0
+		}, nil
+	}
+
+	p.skipSpace()
+	switch {
+	case p.consume("("):
+		e, err := p.parseTernary()
+		if err != nil {
+			return nil, err
+		}
+		if !p.consume(")") {
+			return nil, p.errorf("missing )")
+		}
+		return e, nil
+	case p.consume("n"):
+		return func(n int) int { return n }, nil
+	}
+
+	start := p.pos
+	for p.pos < len(p.src) && p.src[p.pos] >= '0' && p.src[p.pos] <= '9' {
+		p.pos++
+	}
+	if start == p.pos {
+		if p.pos == len(p.src) {
+			return nil, p.errorf("missing operand")
+		}
+		return nil, p.errorf("unexpected %q", p.src[p.pos:p.pos+1])
+	}
+	value, err := strconv.Atoi(p.src[start:p.pos])
+	if err != nil {
+		return nil, p.errorf("invalid number")
+	}
+	return func(int) int { return value }, nil
+}
+
+// operator consumes one of the operators ops, if it is next in the input.
+func (p *pluralParser) operator(ops []string) (string, bool) {
+	p.skipSpace()
+	for _, op := range ops {
+		if !strings.HasPrefix(p.src[p.pos:], op) {
+			continue
+		}
+		// Do not take the < of <=
+		next := p.pos + len(op)
+		if (op == "<" || op == ">") && next < len(p.src) && p.src[next] == '=' {
+			continue
+		}
+		p.pos = next
+		return op, true
+	}
+	return "", false
+}
+
+func (p *pluralParser) consume(token string) bool {
+	p.skipSpace()
+	if strings.HasPrefix(p.src[p.pos:], token) {
+		// Do not take the ! of !=
+		if token == "!" && strings.HasPrefix(p.src[p.pos:], "!=") {
+			return false
+		}
+		p.pos += len(token)
+		return true
+	}
+	return false
+}
+
+func (p *pluralParser) skipSpace() {
+	for p.pos < len(p.src) && (p.src[p.pos] == ' ' || p.src[p.pos] == '\t') {
+		p.pos++
+	}
+}
@@ -0,0 +1,30 @@
+// SPDX-License-Identifier: MIT
+
+// Package goid identifies goroutines, so a batch of binding changes can tell
+// which goroutine started it.
+package goid
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+)
+
+// ID returns the id of the calling goroutine. ok is false if it can not be
+// determined.
+func ID() (id int64, ok bool) {
+	var buf [64]byte
+	// The trace starts with "goroutine <id> [running]:"
+	b, found := bytes.CutPrefix(buf[:runtime.Stack(buf[:], false)], []byte("goroutine "))
+	if !found {
+		return 0, false
+	}
+	if i := bytes.IndexByte(b, ' '); i > 0 {
+		b = b[:i]
+	}
+	id, err := strconv.ParseInt(string(b), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+	return id, true
+}
@@ -9,7 +9,12 @@ import (
 )
 
 func init() {
-	definition.RegisterUIElement((*Flex)(nil), newFlexFromDefinition)
+	definition.RegisterUIElement((*Flex)(nil), newFlexFromDefinition,
+		definition.ConstantProperty[giolayout.Axis]("axis"),
+		definition.ConstantProperty[giolayout.Spacing]("spacing"),
+		definition.ConstantProperty[giolayout.Alignment]("alignment"),
+		definition.ChildrenProperty(),
+	)
 }
 
 // Flex is a layout that arranges its children according to the FlexBox principle.
@@ -76,6 +81,29 @@ func newFlexFromDefinition(
 	return f, nil
 }
 
+// Properties returns the properties of the layout as written in a
+// definition (see definition.Serializable).
+func (f *Flex) Properties() map[string]any {
+	return map[string]any{
+		"axis":      f.flex.Axis,
+		"spacing":   f.flex.Spacing,
+		"alignment": f.flex.Alignment,
+	}
+}
+
+// Children returns the children of the layout, with the weight of the flexed
+// children (see definition.Parent).
+func (f *Flex) Children() []definition.Child {
+	children := make([]definition.Child, 0, len(f.children))
+	for _, child := range f.children {
+		children = append(children, definition.Child{
+			Element: child.element,
+			Weight:  child.weight,
+		})
+	}
+	return children
+}
+
 // AddChild adds a child element to the Flex layout.
 //
 // Parameters:
@@ -98,11 +126,12 @@ func (f *Flex) AddChild(child types.UIElement, weight ...float32) bool {
 
 func (f *Flex) HandleEvents(ctx types.Context) {
 	for _, child := range f.children {
-		child.element.HandleEvents(ctx)
+		types.HandleElementEvents(ctx, child.element)
 	}
 }
 
-// Draw draws the Flex layout using the provided context.
+// Draw draws the Flex layout using the provided context. Hidden children are
+// left out, so they take no space.
 //
 // Parameters:
 // - ctx: The context for the layout.
@@ -112,6 +141,9 @@ func (f *Flex) HandleEvents(ctx types.Context) {
 func (f *Flex) Draw(gtx giolayout.Context) giolayout.Dimensions {
 	children := make([]giolayout.FlexChild, 0, len(f.children))
 	for _, child := range f.children {
+		if !types.IsVisible(child.element) {
+			continue
+		}
 		children = append(children, child.draw())
 	}
 	return f.flex.Layout(gtx, children...)
@@ -124,13 +156,14 @@ type flexChild struct {
 
 // draw returns a giolayout.FlexChild based on the weight of the flexChild.
 //
-// If the weight is nil, it returns a giolayout.Rigid with the result of
-// f.element.Draw.
-// Otherwise, it returns a giolayout.Flexed with the weight and the result of
-// f.element.Draw.
+// If the weight is nil, it returns a giolayout.Rigid drawing f.element.
+// Otherwise, it returns a giolayout.Flexed with the weight drawing f.element.
 func (f *flexChild) draw() giolayout.FlexChild {
+	widget := func(gtx giolayout.Context) giolayout.Dimensions {
+		return types.DrawElement(gtx, f.element)
+	}
 	if f.weight == nil {
-		return giolayout.Rigid(f.element.Draw)
+		return giolayout.Rigid(widget)
 	}
-	return giolayout.Flexed(*f.weight, f.element.Draw)
+	return giolayout.Flexed(*f.weight, widget)
 }
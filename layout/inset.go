@@ -10,7 +10,13 @@ import (
 )
 
 func init() {
-	definition.RegisterUIElement((*Inset)(nil), newInsetFromDefinition)
+	definition.RegisterUIElement((*Inset)(nil), newInsetFromDefinition,
+		definition.NumberProperty("top"),
+		definition.NumberProperty("bottom"),
+		definition.NumberProperty("left"),
+		definition.NumberProperty("right"),
+		definition.ChildProperty(),
+	)
 }
 
 // Inset that adds padding around a child.
@@ -67,6 +73,22 @@ func newInsetFromDefinition(
 	return i, nil
 }
 
+// Properties returns the properties of the layout as written in a
+// definition (see definition.Serializable).
+func (i *Inset) Properties() map[string]any {
+	return map[string]any{
+		"top":    i.inset.Top,
+		"bottom": i.inset.Bottom,
+		"left":   i.inset.Left,
+		"right":  i.inset.Right,
+	}
+}
+
+// Children returns the child of the layout (see definition.Parent).
+func (i *Inset) Children() []definition.Child {
+	return []definition.Child{{Element: i.child}}
+}
+
 func (i *Inset) SetChild(child types.UIElement) {
 	i.child = child
 }
@@ -78,10 +100,12 @@ func (i *Inset) AddChild(child types.UIElement, _ ...float32) bool {
 
 func (i *Inset) HandleEvents(ctx types.Context) {
 	if i.child != nil {
-		i.child.HandleEvents(ctx)
+		types.HandleElementEvents(ctx, i.child)
 	}
 }
 
 func (i *Inset) Draw(gtx giolayout.Context) giolayout.Dimensions {
-	return i.inset.Layout(gtx, i.child.Draw)
+	return i.inset.Layout(gtx, func(gtx giolayout.Context) giolayout.Dimensions {
+		return types.DrawElement(gtx, i.child)
+	})
 }
@@ -9,13 +9,16 @@ import (
 
 // Layout implementation of types.UIElement
 type Layout struct {
+	*types.Conditions
+
 	wnd types.Window
 	id  string
 }
 
 func NewLayout(wnd types.Window, id ...string) *Layout {
 	return &Layout{
-		wnd: wnd,
+		Conditions: types.NewConditions(wnd),
+		wnd:        wnd,
 		id: func() string {
 			if len(id) == 0 {
 				return uuid.NewString()
@@ -38,6 +41,7 @@ func (l Layout) ID() string {
 
 func (l *Layout) SetWnd(wnd types.Window) {
 	l.wnd = wnd
+	l.Conditions.SetWnd(wnd)
 }
 
 func (l *Layout) SetID(id string) {
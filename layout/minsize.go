@@ -10,7 +10,11 @@ import (
 )
 
 func init() {
-	definition.RegisterUIElement((*MinSize)(nil), newMinSizeFromDefinition)
+	definition.RegisterUIElement((*MinSize)(nil), newMinSizeFromDefinition,
+		definition.NumberProperty("minWidth"),
+		definition.NumberProperty("minHeight"),
+		definition.ChildProperty(),
+	)
 }
 
 type MinSize struct {
@@ -51,6 +55,20 @@ func newMinSizeFromDefinition(
 	return ms, nil
 }
 
+// Properties returns the properties of the layout as written in a
+// definition (see definition.Serializable).
+func (ms *MinSize) Properties() map[string]any {
+	return map[string]any{
+		"minWidth":  ms.minWidth,
+		"minHeight": ms.minHeight,
+	}
+}
+
+// Children returns the child of the layout (see definition.Parent).
+func (ms *MinSize) Children() []definition.Child {
+	return []definition.Child{{Element: ms.child}}
+}
+
 func (ms *MinSize) SetChild(child types.UIElement) {
 	ms.child = child
 }
@@ -62,7 +80,7 @@ func (ms *MinSize) AddChild(child types.UIElement, _ ...float32) bool {
 
 func (ms *MinSize) HandleEvents(ctx types.Context) {
 	if ms.child != nil {
-		ms.child.HandleEvents(ctx)
+		types.HandleElementEvents(ctx, ms.child)
 	}
 }
 
@@ -79,7 +97,7 @@ func (ms *MinSize) Draw(gtx giolayout.Context) giolayout.Dimensions {
 			gtx.Constraints.Max.Y = gtx.Constraints.Min.Y
 		}
 	}
-	return ms.child.Draw(gtx)
+	return types.DrawElement(gtx, ms.child)
 
 	/*dim := ms.child.Draw(gtx)
 	if ms.minWidth > 0 && unit.Dp(dim.Size.X) < ms.minWidth {
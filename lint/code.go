@@ -0,0 +1,171 @@
+// SPDX-License-Identifier: MIT
+
+package lint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/mheremans/goui/definition"
+)
+
+// lookupFunctions are the functions looking up elements by id, with the index
+// of the id argument.
+var lookupFunctions = map[string]int{
+	"GetElementById": 1,  // goui.GetElementById[T](v, id)
+	"ElementById":    -1, // definition.ElementById[T](def, id) or def.ElementById(id)
+}
+
+// CheckCode checks the Go files in dir (not its subdirectories) against the
+// definitions docs. It reports the ids looked up with GetElementById or
+// ElementById (e.g. by the code generated by `goui gen`) that no element of
+// docs has, and lookups of an element with another type than the element
+// has.
+//
+// Only ids given as string literals are checked. Elements of item templates
+// are not found by these functions, so they are not taken into account.
+func CheckCode(dir string, docs []*definition.Document) ([]Diagnostic, error) {
+	elements := make(map[string]*definition.ElementInfo)
+	for _, doc := range docs {
+		for _, elem := range doc.Elements() {
+			if elem.ID != "" && !elem.Template {
+				elements[elem.ID] = elem
+			}
+		}
+	}
+
+	fset := token.NewFileSet()
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	var diagnostics []Diagnostic
+	for _, file := range files {
+		f, err := parser.ParseFile(fset, file, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		imports := importNames(f)
+
+		ast.Inspect(f, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			name, typeArg := lookupCall(call.Fun)
+			index, ok := lookupFunctions[name]
+			if !ok || len(call.Args) == 0 {
+				return true
+			}
+			if index < 0 || index >= len(call.Args) {
+				index = len(call.Args) - 1
+			}
+			lit, ok := call.Args[index].(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				return true
+			}
+			id, err := strconv.Unquote(lit.Value)
+			if err != nil {
+				return true
+			}
+
+			pos := fset.Position(lit.Pos())
+			report := func(format string, args ...any) {
+				diagnostics = append(diagnostics, Diagnostic{
+					File:    pos.Filename,
+					Line:    pos.Line,
+					Column:  pos.Column,
+					Message: fmt.Sprintf(format, args...),
+				})
+			}
+
+			elem, ok := elements[id]
+			switch {
+			case !ok:
+				report("no element with id %q in the definitions", id)
+			case typeArg != nil && elem.GoType != nil:
+				if expected, ok := typeName(typeArg, imports); ok && expected != goTypeName(elem.GoType) {
+					report("element %q is a %s, not %s", id, elem.Type, types.ExprString(typeArg))
+				}
+			}
+			return true
+		})
+	}
+	return diagnostics, nil
+}
+
+// lookupCall returns the name of the function called by fun and its type
+// argument, if any.
+func lookupCall(fun ast.Expr) (name string, typeArg ast.Expr) {
+	if index, ok := fun.(*ast.IndexExpr); ok {
+		fun, typeArg = index.X, index.Index
+	}
+	switch f := fun.(type) {
+	case *ast.Ident:
+		name = f.Name
+	case *ast.SelectorExpr:
+		name = f.Sel.Name
+	}
+	return
+}
+
+// importNames returns the package paths of the imports of f, by the name they
+// are referred to by.
+func importNames(f *ast.File) map[string]string {
+	imports := make(map[string]string)
+	for _, spec := range f.Imports {
+		pkgPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		name := path.Base(pkgPath)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		imports[name] = pkgPath
+	}
+	return imports
+}
+
+// typeName returns the name of a pointer type expression like
+// *widget.Input, in the form of goTypeName. Other types are not checked,
+// e.g. interfaces an element may implement.
+func typeName(expr ast.Expr, imports map[string]string) (string, bool) {
+	star, ok := expr.(*ast.StarExpr)
+	if !ok {
+		return "", false
+	}
+	sel, ok := star.X.(*ast.SelectorExpr)
+	if !ok {
+		return "", false
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return "", false
+	}
+	pkgPath, ok := imports[pkg.Name]
+	if !ok {
+		return "", false
+	}
+	return "*" + pkgPath + "." + sel.Sel.Name, true
+}
+
+// goTypeName returns the name of the pointer type t with its package path,
+// e.g. *github.com/mheremans/goui/widget.Input.
+func goTypeName(t reflect.Type) string {
+	prefix := ""
+	for t.Kind() == reflect.Pointer {
+		prefix += "*"
+		t = t.Elem()
+	}
+	return prefix + t.PkgPath() + "." + strings.SplitN(t.Name(), "[", 2)[0]
+}
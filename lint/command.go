@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: MIT
+
+package lint
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/mheremans/goui/definition"
+)
+
+// Run runs the lint command with the command line arguments args (without
+// the command name):
+//
+//	goui lint [-strict=false] [-code dir]... [-json] definition...
+//
+// It checks the definitions (see CheckDefinition), with the probes
+// registered for them (see RegisterProbe), and the Go files in the -code
+// directories against them (see CheckCode).
+//
+// The problems are written to stdout, one per line, as file:line:column:
+// message or, with -json, as a JSON object per line (see Diagnostic) for
+// editors. An error is returned when there are problems.
+func Run(args []string, stdout io.Writer, stderr io.Writer) error {
+	flags := flag.NewFlagSet("lint", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	strict := flags.Bool("strict", true, "report properties that are not in the schema")
+	jsonOutput := flags.Bool("json", false, "write the problems as JSON lines")
+	var codeDirs []string
+	flags.Func("code", "check the element ids used by the Go files in `dir`", func(dir string) error {
+		codeDirs = append(codeDirs, dir)
+		return nil
+	})
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "usage: goui lint [flags] definition...\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return fmt.Errorf("no definition")
+	}
+
+	var diagnostics []Diagnostic
+	var docs []*definition.Document
+	for _, file := range flags.Args() {
+		doc, problems := CheckDefinition(file, Options{
+			Strict: *strict,
+			Probe:  probeFor(file),
+		})
+		diagnostics = append(diagnostics, problems...)
+		if doc != nil {
+			docs = append(docs, doc)
+		}
+	}
+	// Ids can only be checked when all definitions could be loaded
+	if len(docs) == flags.NArg() {
+		for _, dir := range codeDirs {
+			problems, err := CheckCode(dir, docs)
+			if err != nil {
+				return err
+			}
+			diagnostics = append(diagnostics, problems...)
+		}
+	}
+
+	enc := json.NewEncoder(stdout)
+	for _, d := range diagnostics {
+		if *jsonOutput {
+			if err := enc.Encode(d); err != nil {
+				return err
+			}
+		} else {
+			fmt.Fprintln(stdout, d)
+		}
+	}
+
+	switch len(diagnostics) {
+	case 0:
+		return nil
+	case 1:
+		return fmt.Errorf("1 problem")
+	default:
+		return fmt.Errorf("%d problems", len(diagnostics))
+	}
+}
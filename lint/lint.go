@@ -0,0 +1,160 @@
+// SPDX-License-Identifier: MIT
+
+// Package lint checks view definitions without opening a window, see
+// CheckDefinition and CheckCode. It is the implementation of the `goui lint`
+// command.
+package lint
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	giolayout "gioui.org/layout"
+	"github.com/mheremans/goui/definition"
+	"github.com/mheremans/goui/types"
+)
+
+// Diagnostic is a problem found in a definition or in the code using it.
+type Diagnostic struct {
+	File    string `json:"file"`
+	Line    int    `json:"line,omitempty"` // 0 when the position is unknown
+	Column  int    `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+// String formats the diagnostic as file:line:column: message, like
+// definition.Error.
+func (d Diagnostic) String() string {
+	if d.Line == 0 {
+		return fmt.Sprintf("%s: %s", d.File, d.Message)
+	}
+	return fmt.Sprintf("%s:%d:%d: %s", d.File, d.Line, d.Column, d.Message)
+}
+
+// Options configures how a definition is checked.
+type Options struct {
+	// Strict reports properties that are not part of the schema of an
+	// element.
+	Strict bool
+
+	// Registry holds the elements that can be used in the definition, the
+	// default registry when nil.
+	Registry *definition.Registry
+
+	// Probe is the view the functions and bindings referenced by the
+	// definition are checked against, see RegisterProbe. They are not
+	// checked when nil.
+	Probe types.View
+}
+
+// CheckDefinition loads the definition file, with the files it includes, and
+// returns the problems found in it: unknown element types and properties,
+// invalid values (e.g. a misspelled constant or a bad color) and, with a
+// probe, functions and bindings the view does not have.
+//
+// The included files are looked up relative to the current directory, or to
+// the directory of file when it is outside of it. The document is nil when
+// the definition could not be loaded.
+func CheckDefinition(file string, options Options) (*definition.Document, []Diagnostic) {
+	dir := ""
+	name := path.Clean(filepath.ToSlash(file))
+	filesystem := os.DirFS(".")
+	if !fs.ValidPath(name) {
+		dir = filepath.Dir(file)
+		name = filepath.Base(file)
+		filesystem = os.DirFS(dir)
+	}
+
+	var ctx types.Context
+	if options.Probe != nil {
+		ctx = &probeContext{view: options.Probe}
+	}
+	doc, err := definition.Parse(ctx, filesystem, name, definition.Options{
+		Strict:   options.Strict,
+		Registry: options.Registry,
+	})
+	if err == nil {
+		return doc, nil
+	}
+
+	var diagnostics []Diagnostic
+	var errs definition.Errors
+	if errors.As(err, &errs) {
+		for _, e := range errs {
+			diagnostics = append(diagnostics, Diagnostic{
+				File:    filepath.Join(dir, filepath.FromSlash(e.File)),
+				Line:    e.Line,
+				Column:  e.Column,
+				Message: e.Message,
+			})
+		}
+	} else {
+		diagnostics = append(diagnostics, Diagnostic{File: file, Message: err.Error()})
+	}
+	return nil, diagnostics
+}
+
+type probe struct {
+	name    string
+	newView func() types.View
+}
+
+var (
+	probesMu sync.RWMutex
+	probes   []probe
+)
+
+// RegisterProbe registers the view the references of the definition name are
+// checked against (see Options.Probe), e.g.
+//
+//	lint.RegisterProbe("def/timerview.def.yml", func() types.View {
+//		return views.NewTimerView()
+//	})
+//
+// newView creates the view, without initializing it. Its functions must be
+// exported and its view model must have registered its bindings by then.
+// name matches the definitions with that path or ending in it, e.g.
+// views/def/timerview.def.yml.
+//
+// The `goui lint` command only knows the elements of goui and has no probes.
+// An application registers its probes (and elements) in its own command,
+// which calls Run.
+func RegisterProbe(name string, newView func() types.View) {
+	probesMu.Lock()
+	defer probesMu.Unlock()
+	probes = append(probes, probe{name: path.Clean(name), newView: newView})
+}
+
+// probeFor creates the probe registered for the definition file, nil when
+// there is none.
+func probeFor(file string) types.View {
+	file = path.Clean(filepath.ToSlash(file))
+	probesMu.RLock()
+	defer probesMu.RUnlock()
+	for _, p := range probes {
+		if file == p.name || strings.HasSuffix(file, "/"+p.name) {
+			return p.newView()
+		}
+	}
+	return nil
+}
+
+// probeContext is the context a definition is checked in with a probe. It
+// has no window, so translated messages are not checked.
+type probeContext struct {
+	view types.View
+}
+
+func (c *probeContext) Window() types.Window    { return nil }
+func (c *probeContext) View() types.View        { return c.view }
+func (c *probeContext) Gtx() giolayout.Context  { return giolayout.Context{} }
+func (c *probeContext) FontsDir() *embed.FS     { return nil }
+func (c *probeContext) SetFontsDir(*embed.FS)   {}
+func (c *probeContext) SetView(view types.View) { c.view = view }
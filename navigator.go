@@ -0,0 +1,413 @@
+// SPDX-License-Identifier: MIT
+
+package goui
+
+import (
+	"errors"
+	"fmt"
+
+	"gioui.org/io/key"
+	giolayout "gioui.org/layout"
+
+	"github.com/mheremans/goui/types"
+	"github.com/mheremans/goui/widget"
+)
+
+// Route creates the view of a named route, see Navigator.Register.
+type Route struct {
+	// New creates the view, with the parameters it is navigated to with. It
+	// is called again when the view was destroyed on the back-stack and is
+	// navigated back to.
+	New func(params types.RouteParams) (types.View, error)
+
+	// KeepAlive keeps the view initialized while other views are pushed on
+	// top of it, so it keeps its state (see types.Pausable). Otherwise it is
+	// destroyed and created again when it is navigated back to.
+	KeepAlive bool
+}
+
+// Navigator is a view showing the top view of a stack of views, the
+// back-stack. Views are pushed by route name (see Register and Push) or as
+// is (see PushView), Pop goes back to the previous view.
+//
+// The navigator is shown like any other view:
+//
+//	nav := goui.NewNavigator()
+//	nav.Register("timer", goui.Route{New: newTimerView, KeepAlive: true})
+//	nav.Register("settings", goui.Route{New: newSettingsView})
+//	if err := nav.Push("timer", nil); err != nil {
+//		log.Fatal(err)
+//	}
+//	closeChan, err := window.Show(nav)
+//
+// Navigations are applied at the start of the next frame, so they can be
+// executed from the callbacks of the current view. The navigator must only
+// be used from the event loop; other goroutines navigate through
+// Window.Dispatch. Errors of navigations applied at a frame (e.g. a view
+// that fails to initialize) are passed to OnError and kept for Err.
+//
+// Views implementing types.Navigable and types.Pausable are notified when
+// they are shown, left, paused and resumed. The Android back button and the
+// Escape key pop the current view, unless it is the last view: then the back
+// button is left to the system, which closes the application.
+type Navigator struct {
+	*widget.Widget
+
+	// OnError is called on the event loop with the error of the navigations
+	// applied at the start of a frame.
+	OnError func(err error)
+
+	routes  map[string]Route
+	stack   []*navEntry
+	pending []func(ctx types.Context) error // Navigations applied at the next frame
+	depth   int                             // Depth of the stack once pending is applied
+	err     error                           // Error of the last navigations applied
+}
+
+// navEntry is a view on the back-stack.
+type navEntry struct {
+	route     *Route // nil for views pushed with PushView
+	params    types.RouteParams
+	view      types.View
+	keepAlive bool
+	alive     bool // The view is initialized
+}
+
+// NewNavigator creates a navigator without views.
+func NewNavigator(id ...string) *Navigator {
+	return &Navigator{
+		Widget: widget.NewWidget(nil, id...),
+		routes: make(map[string]Route),
+	}
+}
+
+// Register registers the route name, so its view can be pushed by name.
+func (n *Navigator) Register(name string, route Route) {
+	n.routes[name] = route
+}
+
+// Push creates the view of the route name with params and pushes it on top
+// of the current view.
+func (n *Navigator) Push(name string, params types.RouteParams) error {
+	e, err := n.newEntry(name, params)
+	if err != nil {
+		return err
+	}
+	n.navigate(1, func(ctx types.Context) error {
+		return n.push(ctx, e)
+	})
+	return nil
+}
+
+// PushView pushes view on top of the current view. keepAlive keeps it
+// initialized when other views are pushed on top of it, otherwise it is
+// destroyed and initialized again.
+func (n *Navigator) PushView(view types.View, keepAlive bool) {
+	e := &navEntry{view: view, keepAlive: keepAlive}
+	n.navigate(1, func(ctx types.Context) error {
+		return n.push(ctx, e)
+	})
+}
+
+// Replace creates the view of the route name with params and replaces the
+// current view with it. The current view is destroyed.
+func (n *Navigator) Replace(name string, params types.RouteParams) error {
+	e, err := n.newEntry(name, params)
+	if err != nil {
+		return err
+	}
+	n.navigate(n.replaceDelta(), func(ctx types.Context) error {
+		return n.replace(ctx, e)
+	})
+	return nil
+}
+
+// ReplaceView replaces the current view with view, see PushView.
+func (n *Navigator) ReplaceView(view types.View, keepAlive bool) {
+	e := &navEntry{view: view, keepAlive: keepAlive}
+	n.navigate(n.replaceDelta(), func(ctx types.Context) error {
+		return n.replace(ctx, e)
+	})
+}
+
+// Pop destroys the current view and goes back to the previous view. It
+// returns false, without popping, when the current view is the last view.
+func (n *Navigator) Pop() bool {
+	if !n.CanPop() {
+		return false
+	}
+	n.navigate(-1, n.pop)
+	return true
+}
+
+// PopToRoot pops all views except the first one.
+func (n *Navigator) PopToRoot() {
+	for n.CanPop() {
+		n.Pop()
+	}
+}
+
+// CanPop returns whether there is a view to go back to, taking the pending
+// navigations into account.
+func (n *Navigator) CanPop() bool {
+	return n.depth > 1
+}
+
+// Depth returns the number of views on the back-stack, taking the pending
+// navigations into account.
+func (n *Navigator) Depth() int {
+	return n.depth
+}
+
+// Current returns the view that is shown, nil when there is none.
+func (n *Navigator) Current() types.View {
+	if top := n.top(); top != nil {
+		return top.view
+	}
+	return nil
+}
+
+// Root returns the root element of the current view, nil when it has no
+// element tree.
+func (n *Navigator) Root() types.UIElement {
+	if r, ok := n.Current().(interface{ Root() types.UIElement }); ok {
+		return r.Root()
+	}
+	return nil
+}
+
+func (n *Navigator) newEntry(name string, params types.RouteParams) (*navEntry, error) {
+	route, ok := n.routes[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown route %q", name)
+	}
+	if route.New == nil {
+		return nil, fmt.Errorf("route %q creates no view", name)
+	}
+	view, err := route.New(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create view of route %q: %w", name, err)
+	}
+	return &navEntry{route: &route, params: params, view: view, keepAlive: route.KeepAlive}, nil
+}
+
+// replaceDelta returns the change of the depth when replacing the current
+// view, which pushes a view when there is none.
+func (n *Navigator) replaceDelta() int {
+	if n.depth == 0 {
+		return 1
+	}
+	return 0
+}
+
+// navigate queues a navigation changing the depth of the stack by delta.
+func (n *Navigator) navigate(delta int, fn func(ctx types.Context) error) {
+	n.depth += delta
+	n.pending = append(n.pending, fn)
+	if wnd := n.Wnd(); wnd != nil {
+		wnd.Invalidate()
+	}
+}
+
+// apply applies the pending navigations.
+func (n *Navigator) apply(ctx types.Context) error {
+	var errs []error
+	for len(n.pending) > 0 {
+		fn := n.pending[0]
+		n.pending = n.pending[1:]
+		if err := fn(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (n *Navigator) top() *navEntry {
+	if len(n.stack) == 0 {
+		return nil
+	}
+	return n.stack[len(n.stack)-1]
+}
+
+func (n *Navigator) push(ctx types.Context, e *navEntry) error {
+	var err error
+	if top := n.top(); top != nil {
+		err = n.leave(ctx, top, top.keepAlive)
+	}
+	n.stack = append(n.stack, e)
+	return errors.Join(err, n.enter(ctx, e))
+}
+
+func (n *Navigator) replace(ctx types.Context, e *navEntry) error {
+	var err error
+	if top := n.top(); top != nil {
+		n.stack = n.stack[:len(n.stack)-1]
+		err = n.leave(ctx, top, false)
+	}
+	n.stack = append(n.stack, e)
+	return errors.Join(err, n.enter(ctx, e))
+}
+
+func (n *Navigator) pop(ctx types.Context) error {
+	if len(n.stack) < 2 {
+		return nil
+	}
+	top := n.top()
+	n.stack = n.stack[:len(n.stack)-1]
+	err := n.leave(ctx, top, false)
+	return errors.Join(err, n.enter(ctx, n.top()))
+}
+
+// leave navigates away from the view of e, which is paused when pause is
+// set and destroyed otherwise.
+func (n *Navigator) leave(ctx types.Context, e *navEntry, pause bool) error {
+	if !e.alive {
+		return nil
+	}
+	if v, ok := e.view.(types.Navigable); ok {
+		v.OnNavigatedFrom(ctx)
+	}
+	if pause {
+		if v, ok := e.view.(types.Pausable); ok {
+			v.OnPause(ctx)
+		}
+		return nil
+	}
+	e.alive = false
+	err := e.view.Destroy(ctx)
+	if e.route != nil {
+		// Created again when navigated back to
+		e.view = nil
+	}
+	return err
+}
+
+// enter shows the view of e, initializing it when it is not alive.
+func (n *Navigator) enter(ctx types.Context, e *navEntry) error {
+	if e.alive {
+		if v, ok := e.view.(types.Pausable); ok {
+			v.OnResume(ctx)
+		}
+	} else {
+		if err := n.create(ctx, e); err != nil {
+			// Leave the view out, so the previous view is shown
+			n.stack = n.stack[:len(n.stack)-1]
+			n.depth--
+			if top := n.top(); top != nil {
+				return errors.Join(err, n.enter(ctx, top))
+			}
+			return err
+		}
+	}
+	if v, ok := e.view.(types.Navigable); ok {
+		v.OnNavigatedTo(ctx, e.params)
+	}
+	return nil
+}
+
+// create initializes the view of e. Views of routes that were destroyed are
+// created again.
+func (n *Navigator) create(ctx types.Context, e *navEntry) error {
+	if e.view == nil {
+		view, err := e.route.New(e.params)
+		if err != nil {
+			return fmt.Errorf("failed to create view: %w", err)
+		}
+		e.view = view
+	}
+	if err := e.view.Initialize(ctx); err != nil {
+		if e.route != nil {
+			e.view = nil
+		}
+		return fmt.Errorf("failed to initialize view: %w", err)
+	}
+	e.alive = true
+	return nil
+}
+
+func (n *Navigator) Initialize(ctx types.Context) error {
+	n.SetWnd(ctx.Window())
+	return n.apply(ctx)
+}
+
+func (n *Navigator) Destroy(ctx types.Context) error {
+	n.pending = nil
+	var errs []error
+	for i := len(n.stack) - 1; i >= 0; i-- {
+		if err := n.leave(ctx, n.stack[i], false); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	n.stack = nil
+	n.depth = 0
+	return errors.Join(errs...)
+}
+
+// Err returns the error of the navigations applied last, nil if they
+// succeeded.
+func (n *Navigator) Err() error {
+	return n.err
+}
+
+func (n *Navigator) HandleEvents(ctx types.Context) {
+	if len(n.pending) > 0 {
+		n.err = n.apply(ctx)
+		if n.err != nil && n.OnError != nil {
+			n.OnError(n.err)
+		}
+	}
+	top := n.top()
+	if top == nil {
+		return
+	}
+	top.view.HandleEvents(ctx)
+
+	// Only handle the back button when there is a view to go back to, so the
+	// system closes the application otherwise
+	if !n.CanPop() {
+		return
+	}
+	gtx := ctx.Gtx()
+	for {
+		ev, ok := gtx.Event(
+			key.Filter{Name: key.NameBack},
+			key.Filter{Name: key.NameEscape},
+		)
+		if !ok {
+			break
+		}
+		if e, ok := ev.(key.Event); ok && e.State == key.Press {
+			n.Pop()
+		}
+	}
+}
+
+func (n *Navigator) Draw(gtx giolayout.Context) giolayout.Dimensions {
+	if top := n.top(); top != nil {
+		return top.view.Draw(gtx)
+	}
+	return giolayout.Dimensions{}
+}
+
+func (n *Navigator) DrawView(ctx types.Context) giolayout.Dimensions {
+	if top := n.top(); top != nil {
+		return top.view.DrawView(ctx)
+	}
+	return giolayout.Dimensions{}
+}
+
+// FindFunction looks up a function exported by the current view.
+func (n *Navigator) FindFunction(name string) any {
+	if top := n.top(); top != nil {
+		return top.view.FindFunction(name)
+	}
+	return nil
+}
+
+// FindBinding looks up a binding of the current view.
+func (n *Navigator) FindBinding(name string) types.Bindable {
+	if top := n.top(); top != nil {
+		return top.view.FindBinding(name)
+	}
+	return nil
+}
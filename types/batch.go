@@ -0,0 +1,244 @@
+// SPDX-License-Identifier: MIT
+
+package types
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"github.com/mheremans/goui/internal/goid"
+)
+
+// pendingNotification is a notification deferred by a batch.
+type pendingNotification struct {
+	deliver func(merged bool, d dispatchFunc)
+	merged  bool // More than one change of the binding was deferred
+}
+
+// BatchHandle is a batch started by BeginBatch.
+type BatchHandle struct {
+	seq      uint64     // Start order, the outer most batch has the lowest
+	owner    int64      // Goroutine whose changes are deferred, 0 if unknown
+	bindings []*binding // Bindings whose notifications are deferred, nil for all
+
+	mu      sync.Mutex
+	ended   bool
+	pending map[*binding]*pendingNotification
+	order   []*binding
+}
+
+// batchSeq numbers the batches in the order they are started.
+var batchSeq atomic.Uint64
+
+// goroutineBatches are the open batches deferring the notifications of all
+// bindings changed on their goroutine.
+var goroutineBatches struct {
+	mu      sync.Mutex
+	count   atomic.Int32 // Number of open batches, checked without the lock
+	batches []*BatchHandle
+}
+
+// Batch runs fn and defers the notifications of the bindings changed by fn
+// until fn returns.
+//
+// Every binding that changed inside the batch notifies its watchers only
+// once, after all changes have been made, so watchers never observe an
+// intermediate state. The notifications for a window are dispatched together,
+// which invalidates the window only once.
+//
+// Only the changes made on the calling goroutine are deferred, changes made
+// by other goroutines are notified as usual. When bindings are given, only
+// their notifications are deferred. Batches can be nested, the notifications
+// of a binding are delivered when the outer most batch of the binding ends.
+func Batch(fn func(), bindings ...Bindable) {
+	h := BeginBatch(bindings...)
+	defer h.End()
+	fn()
+}
+
+// BeginBatch starts a batch deferring the notifications of the bindings
+// changed on the calling goroutine, or of the given bindings only (see
+// Batch). The returned handle must be ended with End, on the same goroutine.
+// Prefer Batch where possible.
+//
+// It panics when a given binding is not a binding of this package, as its
+// notifications can not be deferred.
+func BeginBatch(bindings ...Bindable) *BatchHandle {
+	cores := make([]*binding, 0, len(bindings))
+	for _, bindable := range bindings {
+		c, ok := bindable.(interface{ core() *binding })
+		if !ok || c.core() == nil {
+			panic(fmt.Sprintf("binding %s can not be batched: it is not a binding of package types", bindable.Name()))
+		}
+		cores = append(cores, c.core())
+	}
+
+	// An unknown goroutine owns no batch, so nothing is deferred
+	owner, _ := goid.ID()
+	h := &BatchHandle{
+		seq:     batchSeq.Add(1),
+		owner:   owner,
+		pending: make(map[*binding]*pendingNotification),
+	}
+	if len(cores) == 0 {
+		goroutineBatches.mu.Lock()
+		goroutineBatches.batches = append(goroutineBatches.batches, h)
+		goroutineBatches.count.Add(1)
+		goroutineBatches.mu.Unlock()
+		return h
+	}
+	for _, b := range cores {
+		b.mu.Lock()
+		b.batches = append(b.batches, h)
+		b.mu.Unlock()
+	}
+	h.bindings = cores
+	return h
+}
+
+// End ends the batch and delivers the deferred notifications.
+func (h *BatchHandle) End() {
+	h.mu.Lock()
+	if h.ended {
+		h.mu.Unlock()
+		return
+	}
+	h.ended = true
+	h.mu.Unlock()
+
+	if h.bindings == nil {
+		goroutineBatches.mu.Lock()
+		goroutineBatches.batches = slices.DeleteFunc(goroutineBatches.batches,
+			func(other *BatchHandle) bool { return other == h })
+		goroutineBatches.count.Add(-1)
+		goroutineBatches.mu.Unlock()
+	}
+	for _, b := range h.bindings {
+		b.leaveBatch(h)
+	}
+
+	groups := newDispatchGroups()
+	for _, b := range h.order {
+		p := h.pending[b]
+		p.deliver(p.merged, groups.dispatch)
+	}
+	groups.flush()
+}
+
+// add defers a notification of b. It returns false when the batch has ended.
+func (h *BatchHandle) add(b *binding, deliver func(bool, dispatchFunc)) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.ended {
+		return false
+	}
+
+	if p, ok := h.pending[b]; ok {
+		p.deliver = deliver
+		p.merged = true
+		return true
+	}
+	h.pending[b] = &pendingNotification{deliver: deliver}
+	h.order = append(h.order, b)
+	return true
+}
+
+// core returns the binding itself, so batches can find the binding embedded
+// in the bindings of this package.
+func (b *binding) core() *binding {
+	return b
+}
+
+// leaveBatch removes the batch h from the batches of the binding.
+func (b *binding) leaveBatch(h *BatchHandle) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	// Copy, deferNotification reads the slice without holding the lock
+	batches := make([]*BatchHandle, 0, len(b.batches))
+	for _, other := range b.batches {
+		if other != h {
+			batches = append(batches, other)
+		}
+	}
+	b.batches = batches
+}
+
+// deferNotification queues a notification in the outer most batch started
+// by the calling goroutine that defers the binding. It returns false if there
+// is no such batch and the notification must be delivered now.
+func (b *binding) deferNotification(deliver func(bool, dispatchFunc)) bool {
+	b.mu.RLock()
+	batches := b.batches
+	b.mu.RUnlock()
+	if len(batches) == 0 && goroutineBatches.count.Load() == 0 {
+		return false
+	}
+
+	id, ok := goid.ID()
+	if !ok {
+		return false
+	}
+	var owned []*BatchHandle
+	for _, h := range batches {
+		if h.owner == id {
+			owned = append(owned, h)
+		}
+	}
+	goroutineBatches.mu.Lock()
+	for _, h := range goroutineBatches.batches {
+		if h.owner == id {
+			owned = append(owned, h)
+		}
+	}
+	goroutineBatches.mu.Unlock()
+
+	slices.SortFunc(owned, func(a, b *BatchHandle) int {
+		return cmp.Compare(a.seq, b.seq)
+	})
+	for _, h := range owned {
+		if h.add(b, deliver) {
+			return true
+		}
+	}
+	return false
+}
+
+// dispatchGroups collects the notifications of a batch per window.
+type dispatchGroups struct {
+	windows []Window
+	fns     map[Window][]func()
+}
+
+func newDispatchGroups() *dispatchGroups {
+	return &dispatchGroups{fns: make(map[Window][]func())}
+}
+
+func (g *dispatchGroups) dispatch(watcher BindingWatcher, fn func()) {
+	if w, ok := watcher.(windowed); ok {
+		if wnd := w.Wnd(); wnd != nil {
+			if _, ok := g.fns[wnd]; !ok {
+				g.windows = append(g.windows, wnd)
+			}
+			g.fns[wnd] = append(g.fns[wnd], fn)
+			return
+		}
+	}
+	fn()
+}
+
+// flush dispatches the collected notifications with a single Dispatch call
+// per window.
+func (g *dispatchGroups) flush() {
+	for _, wnd := range g.windows {
+		fns := g.fns[wnd]
+		deliver := func() {
+			for _, fn := range fns {
+				fn()
+			}
+		}
+		wnd.Dispatch(deliver)
+	}
+}
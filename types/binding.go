@@ -2,6 +2,13 @@
 
 package types
 
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
 type BindableStructValue interface {
 	Less(BindableStructValue) bool
 }
@@ -10,21 +17,56 @@ type BindingWatcher interface {
 	BindingChanged(Bindable)
 }
 
+// Dispatcher runs functions on a specific goroutine (typically the UI event
+// loop).
+type Dispatcher interface {
+	Dispatch(func())
+}
+
 type Bindable interface {
 	Name() string
 	Watch(BindingWatcher)
 	Unwatch(BindingWatcher)
 }
 
+// ValueBinding is a binding holding a single value that can be read.
+type ValueBinding[T any] interface {
+	Bindable
+	Get() T
+}
+
+// MutableBinding is a ValueBinding that can also be written, e.g. by widgets
+// that edit the value.
+type MutableBinding[T any] interface {
+	ValueBinding[T]
+	Set(T)
+}
+
+// Initializable is implemented by bindings that can be initialized in place,
+// so a zero value can be used as a binding. It is used by goui.AutoRegister to
+// create missing bindings.
+type Initializable interface {
+	Bindable
+	Init(name string)
+}
+
 type BindableList interface {
 	Bindable
 	GetAt(int) (any, bool)
 	Size() int
 }
 
+// windowed is implemented by watchers that live on a window (UI elements and
+// views). Their notifications are dispatched on the window's event loop.
+type windowed interface {
+	Wnd() Window
+}
+
 type binding struct {
+	mu       sync.RWMutex
 	name     string
 	watchers map[BindingWatcher]struct{}
+	batches  []*BatchHandle // Open batches deferring the notifications, see Batch
 }
 
 func newBinding(name string) *binding {
@@ -34,27 +76,102 @@ func newBinding(name string) *binding {
 	}
 }
 
-func (b binding) Name() string {
+func (b *binding) Name() string {
 	return b.name
 }
 
 func (b *binding) Watch(watcher BindingWatcher) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
 	b.watchers[watcher] = struct{}{}
 }
 
 func (b *binding) Unwatch(watcher BindingWatcher) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
 	delete(b.watchers, watcher)
 }
 
+// notify informs all watchers about a change of the binding.
+//
+// Watchers that belong to a window are notified on the window's event loop,
+// through its Dispatcher. The event loop runs the dispatched notifications
+// before the view handles its events and again before it is drawn, so a
+// change made while handling the events is drawn in the same frame. All
+// other watchers are notified directly on the calling goroutine. Inside a
+// Batch the notification is deferred until the batch ends. Must be called
+// without holding the lock.
 func (b *binding) notify(binding Bindable) {
+	deliver := func(_ bool, d dispatchFunc) {
+		for _, w := range b.watcherList() {
+			d(w, func() {
+				w.BindingChanged(binding)
+			})
+		}
+	}
+	if !b.deferNotification(deliver) {
+		deliver(false, dispatch)
+	}
+}
+
+// notifyList informs all watchers about a change of a list binding.
+//
+// ListBindingWatchers receive the change itself, all other watchers are
+// notified like by notify. When several changes are merged by a Batch,
+// ListBindingWatchers receive a single ListReset.
+func (b *binding) notifyList(list BindableList, change ListChange) {
+	deliver := func(merged bool, d dispatchFunc) {
+		change := change
+		if merged {
+			change = ListChange{Kind: ListReset, Count: list.Size()}
+		}
+		for _, w := range b.watcherList() {
+			if lw, ok := w.(ListBindingWatcher); ok {
+				d(w, func() {
+					lw.ListBindingChanged(list, change)
+				})
+				continue
+			}
+			d(w, func() {
+				w.BindingChanged(list)
+			})
+		}
+	}
+	if !b.deferNotification(deliver) {
+		deliver(false, dispatch)
+	}
+}
+
+func (b *binding) watcherList() []BindingWatcher {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	watchers := make([]BindingWatcher, 0, len(b.watchers))
 	for w := range b.watchers {
-		w.BindingChanged(binding)
+		watchers = append(watchers, w)
 	}
+	return watchers
+}
+
+// dispatchFunc delivers a notification (fn) to a watcher.
+type dispatchFunc func(watcher BindingWatcher, fn func())
+
+func dispatch(watcher BindingWatcher, fn func()) {
+	if w, ok := watcher.(windowed); ok {
+		if wnd := w.Wnd(); wnd != nil {
+			wnd.Dispatch(fn)
+			return
+		}
+	}
+	fn()
 }
 
 type Binding[T comparable] struct {
 	*binding
 	value T
+
+	validators []Validator[T]
+	errs       []error
+	valid      *Binding[bool]
 }
 
 func NewBinding[T comparable](name string, value T) *Binding[T] {
@@ -64,15 +181,86 @@ func NewBinding[T comparable](name string, value T) *Binding[T] {
 	}
 }
 
+// Init initializes a zero value Binding with the given name.
+func (b *Binding[T]) Init(name string) {
+	if b.binding == nil {
+		b.binding = newBinding(name)
+	}
+}
+
+func (b Binding[T]) anyValue() any {
+	return b.Get()
+}
+
+func (b *Binding[T]) setAnyValue(value any) {
+	b.Set(value.(T))
+}
+
 func (b Binding[T]) Get() T {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
 	return b.value
 }
 
+// Set sets the value and runs the validators on it. Invalid values are
+// stored as well, their errors are reported by Errors.
 func (b *Binding[T]) Set(value T) {
-	if value != b.value {
-		b.value = value
-		b.notify(b)
+	b.mu.Lock()
+	if value == b.value {
+		b.mu.Unlock()
+		return
+	}
+	b.value = value
+	b.errs = validate(b.validators, value)
+	valid := b.valid
+	isValid := len(b.errs) == 0
+	b.mu.Unlock()
+
+	if valid != nil {
+		valid.Set(isValid)
+	}
+	b.notify(b)
+}
+
+// AddValidators adds validators and validates the current value.
+//
+// It returns the binding, so it can be chained to NewBinding.
+func (b *Binding[T]) AddValidators(validators ...Validator[T]) *Binding[T] {
+	valid := b.Valid()
+
+	b.mu.Lock()
+	b.validators = append(b.validators, validators...)
+	b.errs = validate(b.validators, b.value)
+	isValid := len(b.errs) == 0
+	b.mu.Unlock()
+
+	valid.Set(isValid)
+	b.notify(b)
+	return b
+}
+
+// Errors returns the validation errors of the current value.
+func (b Binding[T]) Errors() []error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return append([]error(nil), b.errs...)
+}
+
+// Err returns all validation errors of the current value joined together, or
+// nil if the value is valid.
+func (b Binding[T]) Err() error {
+	return errors.Join(b.Errors()...)
+}
+
+// Valid returns a binding that is true while the value is valid. It is named
+// after the binding with a " Valid" suffix.
+func (b *Binding[T]) Valid() *Binding[bool] {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.valid == nil {
+		b.valid = NewBinding(b.name+" Valid", len(b.errs) == 0)
 	}
+	return b.valid
 }
 
 type StructBinding[T BindableStructValue] struct {
@@ -87,36 +275,84 @@ func NewStructBinding[T BindableStructValue](name string, value T) *StructBindin
 	}
 }
 
+// Init initializes a zero value StructBinding with the given name.
+func (b *StructBinding[T]) Init(name string) {
+	if b.binding == nil {
+		b.binding = newBinding(name)
+	}
+}
+
+func (b StructBinding[T]) anyValue() any {
+	return b.Get()
+}
+
+func (b *StructBinding[T]) setAnyValue(value any) {
+	b.Set(value.(T))
+}
+
 func (b StructBinding[T]) Get() T {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
 	return b.value
 }
 
 func (b *StructBinding[T]) Set(value T) {
-	if value.Less(b.value) || b.value.Less(value) {
-		b.value = value
-		b.notify(b)
+	b.mu.Lock()
+	if !value.Less(b.value) && !b.value.Less(value) {
+		b.mu.Unlock()
+		return
 	}
+	b.value = value
+	b.mu.Unlock()
+	b.notify(b)
 }
 
 type ListBinding[T comparable] struct {
 	*binding
-	list []T
+	list   []T
+	shared *atomic.Bool // The list was returned by Get, so it is copied on write
 }
 
 func NewListBinding[T comparable](name string, values []T) *ListBinding[T] {
 	b := &ListBinding[T]{
 		binding: newBinding(name),
 		list:    make([]T, 0, len(values)),
+		shared:  new(atomic.Bool),
 	}
 	b.list = append(b.list, values...)
 	return b
 }
 
+func (b ListBinding[T]) anyValue() any {
+	return b.Get()
+}
+
+func (b *ListBinding[T]) setAnyValue(value any) {
+	b.Set(value.([]T))
+}
+
+// Get returns the items of the list. The returned slice must not be modified.
+// It is not changed by later changes of the list, which copy it first.
 func (b ListBinding[T]) Get() []T {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	b.shared.Store(true)
 	return b.list
 }
 
+// Init initializes a zero value ListBinding with the given name.
+func (b *ListBinding[T]) Init(name string) {
+	if b.binding == nil {
+		b.binding = newBinding(name)
+	}
+	if b.shared == nil {
+		b.shared = new(atomic.Bool)
+	}
+}
+
 func (b ListBinding[T]) GetAt(index int) (any, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
 	if index < 0 || index >= len(b.list) {
 		return *new(T), false
 	}
@@ -124,17 +360,111 @@ func (b ListBinding[T]) GetAt(index int) (any, bool) {
 }
 
 func (b ListBinding[T]) Size() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
 	return len(b.list)
 }
 
 func (b *ListBinding[T]) Set(values []T) {
+	b.mu.Lock()
 	if b.equalValues(values) {
+		b.mu.Unlock()
 		return
 	}
 
 	b.list = make([]T, 0, len(values))
 	b.list = append(b.list, values...)
-	b.notify(b)
+	b.shared.Store(false)
+	b.mu.Unlock()
+	b.notifyList(b, ListChange{Kind: ListReset, Count: len(values)})
+}
+
+// Append adds values at the end of the list.
+func (b *ListBinding[T]) Append(values ...T) {
+	if len(values) == 0 {
+		return
+	}
+	b.mu.Lock()
+	index := len(b.list)
+	b.list, _ = insertValues(ownList(b.list, b.shared), index, values)
+	b.mu.Unlock()
+	b.notifyList(b, ListChange{Kind: ListInsert, Index: index, Count: len(values)})
+}
+
+// Insert inserts values at index. It returns false if index is out of range.
+func (b *ListBinding[T]) Insert(index int, values ...T) bool {
+	if len(values) == 0 {
+		return true
+	}
+	b.mu.Lock()
+	list, ok := insertValues(ownList(b.list, b.shared), index, values)
+	b.list = list
+	b.mu.Unlock()
+	if ok {
+		b.notifyList(b, ListChange{Kind: ListInsert, Index: index, Count: len(values)})
+	}
+	return ok
+}
+
+// RemoveAt removes count items (default 1) starting at index. It returns
+// false if the range is out of bounds.
+func (b *ListBinding[T]) RemoveAt(index int, count ...int) bool {
+	n := countArg(count)
+	b.mu.Lock()
+	list, ok := removeValues(ownList(b.list, b.shared), index, n)
+	b.list = list
+	b.mu.Unlock()
+	if ok {
+		b.notifyList(b, ListChange{Kind: ListRemove, Index: index, Count: n})
+	}
+	return ok
+}
+
+// Move moves the item at index from to index to. It returns false if one of
+// the indices is out of range.
+func (b *ListBinding[T]) Move(from int, to int) bool {
+	if from == to {
+		return true
+	}
+	b.mu.Lock()
+	list, ok := moveValue(ownList(b.list, b.shared), from, to)
+	b.list = list
+	b.mu.Unlock()
+	if ok {
+		b.notifyList(b, ListChange{Kind: ListMove, Index: from, Count: 1, To: to})
+	}
+	return ok
+}
+
+// Replace overwrites the items starting at index with values. It returns
+// false if the range is out of bounds. Items replaced by identical values
+// are not reported as a change.
+func (b *ListBinding[T]) Replace(index int, values ...T) bool {
+	if len(values) == 0 {
+		return true
+	}
+	b.mu.Lock()
+	if sameValues(b.list, index, values, func(x, y T) bool { return x == y }) {
+		// Identical values, nothing to notify
+		b.mu.Unlock()
+		return true
+	}
+	list, ok := replaceValues(ownList(b.list, b.shared), index, values)
+	b.list = list
+	b.mu.Unlock()
+	if ok {
+		b.notifyList(b, ListChange{Kind: ListReplace, Index: index, Count: len(values)})
+	}
+	return ok
+}
+
+// replaceAny replaces the item at index, if value is of the item type.
+func (b *ListBinding[T]) replaceAny(index int, value any) bool {
+	v, ok := value.(T)
+	if !ok {
+		return false
+	}
+	return b.Replace(index, v)
 }
 
 func (b ListBinding[T]) equalValues(other []T) bool {
@@ -151,23 +481,50 @@ func (b ListBinding[T]) equalValues(other []T) bool {
 
 type StructListBinding[T BindableStructValue] struct {
 	*binding
-	list []T
+	list   []T
+	shared *atomic.Bool // The list was returned by Get, so it is copied on write
 }
 
 func NewStructListBinding[T BindableStructValue](name string, values []T) *StructListBinding[T] {
 	b := &StructListBinding[T]{
 		binding: newBinding(name),
 		list:    make([]T, 0, len(values)),
+		shared:  new(atomic.Bool),
 	}
 	b.list = append(b.list, values...)
 	return b
 }
 
+func (b StructListBinding[T]) anyValue() any {
+	return b.Get()
+}
+
+func (b *StructListBinding[T]) setAnyValue(value any) {
+	b.Set(value.([]T))
+}
+
+// Get returns the items of the list. The returned slice must not be modified.
+// It is not changed by later changes of the list, which copy it first.
 func (b StructListBinding[T]) Get() []T {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	b.shared.Store(true)
 	return b.list
 }
 
+// Init initializes a zero value StructListBinding with the given name.
+func (b *StructListBinding[T]) Init(name string) {
+	if b.binding == nil {
+		b.binding = newBinding(name)
+	}
+	if b.shared == nil {
+		b.shared = new(atomic.Bool)
+	}
+}
+
 func (b StructListBinding[T]) GetAt(index int) (any, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
 	if index < 0 || index >= len(b.list) {
 		return *new(T), false
 	}
@@ -175,17 +532,111 @@ func (b StructListBinding[T]) GetAt(index int) (any, bool) {
 }
 
 func (b StructListBinding[T]) Size() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
 	return len(b.list)
 }
 
 func (b *StructListBinding[T]) Set(values []T) {
+	b.mu.Lock()
 	if b.equalValues(values) {
+		b.mu.Unlock()
 		return
 	}
 
 	b.list = make([]T, 0, len(values))
 	b.list = append(b.list, values...)
-	b.notify(b)
+	b.shared.Store(false)
+	b.mu.Unlock()
+	b.notifyList(b, ListChange{Kind: ListReset, Count: len(values)})
+}
+
+// Append adds values at the end of the list.
+func (b *StructListBinding[T]) Append(values ...T) {
+	if len(values) == 0 {
+		return
+	}
+	b.mu.Lock()
+	index := len(b.list)
+	b.list, _ = insertValues(ownList(b.list, b.shared), index, values)
+	b.mu.Unlock()
+	b.notifyList(b, ListChange{Kind: ListInsert, Index: index, Count: len(values)})
+}
+
+// Insert inserts values at index. It returns false if index is out of range.
+func (b *StructListBinding[T]) Insert(index int, values ...T) bool {
+	if len(values) == 0 {
+		return true
+	}
+	b.mu.Lock()
+	list, ok := insertValues(ownList(b.list, b.shared), index, values)
+	b.list = list
+	b.mu.Unlock()
+	if ok {
+		b.notifyList(b, ListChange{Kind: ListInsert, Index: index, Count: len(values)})
+	}
+	return ok
+}
+
+// RemoveAt removes count items (default 1) starting at index. It returns
+// false if the range is out of bounds.
+func (b *StructListBinding[T]) RemoveAt(index int, count ...int) bool {
+	n := countArg(count)
+	b.mu.Lock()
+	list, ok := removeValues(ownList(b.list, b.shared), index, n)
+	b.list = list
+	b.mu.Unlock()
+	if ok {
+		b.notifyList(b, ListChange{Kind: ListRemove, Index: index, Count: n})
+	}
+	return ok
+}
+
+// Move moves the item at index from to index to. It returns false if one of
+// the indices is out of range.
+func (b *StructListBinding[T]) Move(from int, to int) bool {
+	if from == to {
+		return true
+	}
+	b.mu.Lock()
+	list, ok := moveValue(ownList(b.list, b.shared), from, to)
+	b.list = list
+	b.mu.Unlock()
+	if ok {
+		b.notifyList(b, ListChange{Kind: ListMove, Index: from, Count: 1, To: to})
+	}
+	return ok
+}
+
+// Replace overwrites the items starting at index with values. It returns
+// false if the range is out of bounds. Items replaced by identical values
+// are not reported as a change.
+func (b *StructListBinding[T]) Replace(index int, values ...T) bool {
+	if len(values) == 0 {
+		return true
+	}
+	b.mu.Lock()
+	if sameValues(b.list, index, values, func(x, y T) bool { return !x.Less(y) && !y.Less(x) }) {
+		// Identical values, nothing to notify
+		b.mu.Unlock()
+		return true
+	}
+	list, ok := replaceValues(ownList(b.list, b.shared), index, values)
+	b.list = list
+	b.mu.Unlock()
+	if ok {
+		b.notifyList(b, ListChange{Kind: ListReplace, Index: index, Count: len(values)})
+	}
+	return ok
+}
+
+// replaceAny replaces the item at index, if value is of the item type.
+func (b *StructListBinding[T]) replaceAny(index int, value any) bool {
+	v, ok := value.(T)
+	if !ok {
+		return false
+	}
+	return b.Replace(index, v)
 }
 
 func (b StructListBinding[T]) equalValues(other []T) bool {
@@ -199,3 +650,16 @@ func (b StructListBinding[T]) equalValues(other []T) bool {
 	}
 	return true
 }
+
+// BindingValue returns the current value of a binding with a Get method (e.g.
+// any ValueBinding), without knowing its value type.
+func BindingValue(b Bindable) (any, bool) {
+	if b == nil {
+		return nil, false
+	}
+	get := reflect.ValueOf(b).MethodByName("Get")
+	if !get.IsValid() || get.Type().NumIn() != 0 || get.Type().NumOut() != 1 {
+		return nil, false
+	}
+	return get.Call(nil)[0].Interface(), true
+}
@@ -0,0 +1,11 @@
+// SPDX-License-Identifier: MIT
+
+package types
+
+// Command is an action that can be bound to widgets, e.g. with the `command:`
+// key of a button. Widgets are disabled while CanExecute is false.
+type Command interface {
+	Name() string
+	Execute(Context)
+	CanExecute() ValueBinding[bool]
+}
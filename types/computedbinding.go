@@ -0,0 +1,138 @@
+// SPDX-License-Identifier: MIT
+
+package types
+
+// ComputedBinding is a read-only binding whose value is derived from one or
+// more source bindings.
+//
+// The value is recomputed every time one of the sources changes and watchers
+// are only notified when the computed value differs from the previous one.
+// A ComputedBinding has no Set method, so widgets that require a
+// MutableBinding will refuse to bind to it.
+type ComputedBinding[T comparable] struct {
+	*binding
+	value   T
+	compute func() T
+	sources []Bindable
+
+	lazy     bool
+	watching bool
+}
+
+// NewComputedBinding creates a new ComputedBinding.
+//
+// Parameters:
+// - name: the name of the binding.
+// - compute: a pure function calculating the value from the sources.
+// - sources: the bindings the value depends on.
+//
+// Returns:
+// - *ComputedBinding[T]: the computed binding, already holding the initial
+// value.
+func NewComputedBinding[T comparable](
+	name string,
+	compute func() T,
+	sources ...Bindable,
+) *ComputedBinding[T] {
+	b := &ComputedBinding[T]{
+		binding: newBinding(name),
+		value:   compute(),
+		compute: compute,
+		sources: sources,
+	}
+	for _, src := range b.sources {
+		src.Watch(b)
+	}
+	return b
+}
+
+// NewLazyComputedBinding creates a ComputedBinding that only watches its
+// sources while it has watchers itself, so it can be dropped without being
+// detached. While it has no watchers, Get computes the value on every call.
+func NewLazyComputedBinding[T comparable](
+	name string,
+	compute func() T,
+	sources ...Bindable,
+) *ComputedBinding[T] {
+	return &ComputedBinding[T]{
+		binding: newBinding(name),
+		compute: compute,
+		sources: sources,
+		lazy:    true,
+	}
+}
+
+func (b ComputedBinding[T]) Get() T {
+	b.mu.RLock()
+	if b.lazy && !b.watching {
+		b.mu.RUnlock()
+		return b.compute()
+	}
+	defer b.mu.RUnlock()
+	return b.value
+}
+
+// Watch adds a watcher. A lazy binding starts watching its sources with the
+// first watcher.
+func (b *ComputedBinding[T]) Watch(watcher BindingWatcher) {
+	b.mu.Lock()
+	start := b.lazy && !b.watching
+	b.watchers[watcher] = struct{}{}
+	b.watching = b.watching || start
+	b.mu.Unlock()
+
+	if start {
+		b.mu.Lock()
+		b.value = b.compute()
+		b.mu.Unlock()
+		for _, src := range b.sources {
+			src.Watch(b)
+		}
+	}
+}
+
+// Unwatch removes a watcher. A lazy binding stops watching its sources when
+// the last watcher is removed.
+func (b *ComputedBinding[T]) Unwatch(watcher BindingWatcher) {
+	b.mu.Lock()
+	delete(b.watchers, watcher)
+	stop := b.lazy && b.watching && len(b.watchers) == 0
+	if stop {
+		b.watching = false
+	}
+	b.mu.Unlock()
+
+	if stop {
+		b.Detach()
+	}
+}
+
+// Sources returns the bindings the value is computed from.
+func (b ComputedBinding[T]) Sources() []Bindable {
+	return b.sources
+}
+
+// Detach stops watching the sources. The binding keeps its last value.
+func (b *ComputedBinding[T]) Detach() {
+	for _, src := range b.sources {
+		src.Unwatch(b)
+	}
+}
+
+// BindingChanged recomputes the value when one of the sources changed.
+func (b *ComputedBinding[T]) BindingChanged(Bindable) {
+	b.recompute()
+}
+
+func (b *ComputedBinding[T]) recompute() {
+	value := b.compute()
+
+	b.mu.Lock()
+	if value == b.value {
+		b.mu.Unlock()
+		return
+	}
+	b.value = value
+	b.mu.Unlock()
+	b.notify(b)
+}
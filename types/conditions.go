@@ -0,0 +1,186 @@
+// SPDX-License-Identifier: MIT
+
+package types
+
+import (
+	"gioui.org/layout"
+	"gioui.org/op/paint"
+)
+
+// disabledOpacity is the opacity disabled elements are drawn with.
+const disabledOpacity = 0.5
+
+// Conditional is implemented by elements that can be hidden and disabled.
+// Hidden elements are not drawn and take no space, disabled elements ignore
+// input and are drawn greyed out (see DrawElement and HandleElementEvents).
+type Conditional interface {
+	Visible() bool
+	Enabled() bool
+	SetVisible(bool)
+	SetEnabled(bool)
+	BindVisible(ValueBinding[bool])
+	BindEnabled(ValueBinding[bool])
+}
+
+// Conditions implements Conditional. It is embedded in the base types of the
+// UI elements (widget.Widget and layout.Layout), so every element can be
+// hidden and disabled.
+type Conditions struct {
+	watcher  *conditionsWatcher
+	hidden   bool
+	disabled bool
+
+	visibleBinding ValueBinding[bool]
+	enabledBinding ValueBinding[bool]
+}
+
+func NewConditions(wnd Window) *Conditions {
+	c := new(Conditions)
+	c.watcher = &conditionsWatcher{conditions: c, wnd: wnd}
+	return c
+}
+
+// SetWnd sets the window that is invalidated when a condition changes.
+func (c *Conditions) SetWnd(wnd Window) {
+	c.watcher.wnd = wnd
+}
+
+func (c *Conditions) Visible() bool {
+	return !c.hidden
+}
+
+func (c *Conditions) Enabled() bool {
+	return !c.disabled
+}
+
+func (c *Conditions) SetVisible(visible bool) {
+	c.hidden = !visible
+	c.watcher.invalidate()
+}
+
+func (c *Conditions) SetEnabled(enabled bool) {
+	c.disabled = !enabled
+	c.watcher.invalidate()
+}
+
+// BindVisible shows or hides the element according to the given binding.
+func (c *Conditions) BindVisible(binding ValueBinding[bool]) {
+	if c.visibleBinding != nil {
+		c.visibleBinding.Unwatch(c.watcher)
+		c.visibleBinding = nil
+	}
+
+	if binding == nil {
+		return
+	}
+
+	c.visibleBinding = binding
+	c.visibleBinding.Watch(c.watcher)
+	c.SetVisible(c.visibleBinding.Get())
+}
+
+// BindEnabled enables or disables the element according to the given
+// binding.
+func (c *Conditions) BindEnabled(binding ValueBinding[bool]) {
+	if c.enabledBinding != nil {
+		c.enabledBinding.Unwatch(c.watcher)
+		c.enabledBinding = nil
+	}
+
+	if binding == nil {
+		return
+	}
+
+	c.enabledBinding = binding
+	c.enabledBinding.Watch(c.watcher)
+	c.SetEnabled(c.enabledBinding.Get())
+}
+
+// VisibleBinding returns the binding set with BindVisible, or nil.
+func (c *Conditions) VisibleBinding() ValueBinding[bool] {
+	return c.visibleBinding
+}
+
+// EnabledBinding returns the binding set with BindEnabled, or nil.
+func (c *Conditions) EnabledBinding() ValueBinding[bool] {
+	return c.enabledBinding
+}
+
+// conditionsWatcher watches the bindings of Conditions. It is a separate type,
+// so the elements embedding Conditions do not become BindingWatchers.
+type conditionsWatcher struct {
+	conditions *Conditions
+	wnd        Window
+}
+
+func (w *conditionsWatcher) Wnd() Window {
+	return w.wnd
+}
+
+func (w *conditionsWatcher) invalidate() {
+	if w.wnd != nil {
+		w.wnd.Invalidate()
+	}
+}
+
+func (w *conditionsWatcher) BindingChanged(binding Bindable) {
+	c := w.conditions
+	switch binding {
+	case c.visibleBinding:
+		c.SetVisible(c.visibleBinding.Get())
+	case c.enabledBinding:
+		c.SetEnabled(c.enabledBinding.Get())
+	}
+}
+
+// DrawTracer is implemented by windows that trace the elements drawn with
+// DrawElement, e.g. the headless window of package gouitest.
+type DrawTracer interface {
+	// TraceElement draws elem with draw, which draws it like DrawElement
+	// does without a tracer. The elements drawn by draw are drawn by elem,
+	// e.g. the children of a layout.
+	TraceElement(gtx layout.Context, elem UIElement, draw func(gtx layout.Context) layout.Dimensions) layout.Dimensions
+}
+
+// DrawElement draws elem, taking its conditions into account (see
+// Conditional). Layouts draw their children with DrawElement.
+func DrawElement(gtx layout.Context, elem UIElement) layout.Dimensions {
+	if tracer, ok := elem.Wnd().(DrawTracer); ok {
+		return tracer.TraceElement(gtx, elem, func(gtx layout.Context) layout.Dimensions {
+			return drawElement(gtx, elem)
+		})
+	}
+	return drawElement(gtx, elem)
+}
+
+func drawElement(gtx layout.Context, elem UIElement) layout.Dimensions {
+	c, ok := elem.(Conditional)
+	if !ok {
+		return elem.Draw(gtx)
+	}
+	if !c.Visible() {
+		return layout.Dimensions{}
+	}
+	if !c.Enabled() {
+		gtx = gtx.Disabled()
+		defer paint.PushOpacity(gtx.Ops, disabledOpacity).Pop()
+	}
+	return elem.Draw(gtx)
+}
+
+// HandleElementEvents lets elem handle its events, unless it is hidden or
+// disabled. Layouts handle the events of their children with
+// HandleElementEvents.
+func HandleElementEvents(ctx Context, elem UIElement) {
+	if c, ok := elem.(Conditional); ok && (!c.Visible() || !c.Enabled()) {
+		return
+	}
+	elem.HandleEvents(ctx)
+}
+
+// IsVisible returns whether elem is visible. Elements that do not implement
+// Conditional are always visible.
+func IsVisible(elem UIElement) bool {
+	c, ok := elem.(Conditional)
+	return !ok || c.Visible()
+}
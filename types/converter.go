@@ -0,0 +1,244 @@
+// SPDX-License-Identifier: MIT
+
+package types
+
+import (
+	"strconv"
+	"time"
+)
+
+// Converter converts values between the type of a binding (From) and the
+// type a widget works with (To).
+type Converter[From, To any] interface {
+	Convert(From) (To, error)
+	ConvertBack(To) (From, error)
+}
+
+// BindingAdapter adapts a binding of an arbitrary type to a MutableBinding of
+// type To. It is used by definitions to resolve a `converter:` without knowing
+// the type of the bound value.
+type BindingAdapter[To any] interface {
+	Adapt(Bindable) (MutableBinding[To], bool)
+}
+
+// ErrorReporter is implemented by bindings that can fail to accept a value.
+type ErrorReporter interface {
+	Err() error
+}
+
+// FuncConverter is a Converter built from a pair of functions.
+//
+// It also implements BindingAdapter, so it can be exported by a view and
+// referenced by name with the `converter:` key in a definition.
+type FuncConverter[From, To comparable] struct {
+	convert     func(From) (To, error)
+	convertBack func(To) (From, error)
+}
+
+// NewConverter creates a new FuncConverter.
+//
+// Parameters:
+// - convert: converts the value of the binding to the widget type.
+// - convertBack: converts the widget value back to the binding type.
+//
+// Returns:
+// - *FuncConverter[From, To]: the converter.
+func NewConverter[From, To comparable](
+	convert func(From) (To, error),
+	convertBack func(To) (From, error),
+) *FuncConverter[From, To] {
+	return &FuncConverter[From, To]{
+		convert:     convert,
+		convertBack: convertBack,
+	}
+}
+
+func (c FuncConverter[From, To]) Convert(value From) (To, error) {
+	return c.convert(value)
+}
+
+func (c FuncConverter[From, To]) ConvertBack(value To) (From, error) {
+	return c.convertBack(value)
+}
+
+func (c *FuncConverter[From, To]) Adapt(binding Bindable) (MutableBinding[To], bool) {
+	source, ok := binding.(MutableBinding[From])
+	if !ok {
+		return nil, false
+	}
+	return NewConvertedBinding[From, To](source, c), true
+}
+
+// ConvertedBinding presents a MutableBinding[From] as a MutableBinding[To].
+//
+// Values set on the ConvertedBinding are converted back and written to the
+// source binding. When that conversion fails, the value is kept (so a widget
+// does not lose the user input), the source is left untouched and the error
+// is reported by Err.
+type ConvertedBinding[From, To comparable] struct {
+	*binding
+	source    MutableBinding[From]
+	converter Converter[From, To]
+	value     To
+	err       error
+}
+
+// NewConvertedBinding creates a new ConvertedBinding for the given source
+// binding. It carries the name of the source binding.
+func NewConvertedBinding[From, To comparable](
+	source MutableBinding[From],
+	converter Converter[From, To],
+) *ConvertedBinding[From, To] {
+	b := &ConvertedBinding[From, To]{
+		binding:   newBinding(source.Name()),
+		source:    source,
+		converter: converter,
+	}
+	b.value, b.err = converter.Convert(source.Get())
+	return b
+}
+
+// Watch adds a watcher. The source binding is only watched as long as the
+// ConvertedBinding has watchers itself.
+func (b *ConvertedBinding[From, To]) Watch(watcher BindingWatcher) {
+	b.mu.Lock()
+	first := len(b.watchers) == 0
+	b.watchers[watcher] = struct{}{}
+	b.mu.Unlock()
+
+	if first {
+		b.source.Watch(b)
+		b.BindingChanged(b.source)
+	}
+}
+
+func (b *ConvertedBinding[From, To]) Unwatch(watcher BindingWatcher) {
+	b.mu.Lock()
+	delete(b.watchers, watcher)
+	last := len(b.watchers) == 0
+	b.mu.Unlock()
+
+	if last {
+		b.source.Unwatch(b)
+	}
+}
+
+func (b ConvertedBinding[From, To]) Get() To {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.value
+}
+
+// Err returns the error of the last conversion. If the conversion succeeded,
+// the error reported by the source binding (e.g. a validation error) is
+// returned.
+func (b ConvertedBinding[From, To]) Err() error {
+	b.mu.RLock()
+	err := b.err
+	b.mu.RUnlock()
+
+	if err != nil {
+		return err
+	}
+	if reporter, ok := b.source.(ErrorReporter); ok {
+		return reporter.Err()
+	}
+	return nil
+}
+
+// Source returns the binding that is converted.
+func (b ConvertedBinding[From, To]) Source() MutableBinding[From] {
+	return b.source
+}
+
+// Converter returns the converter between the source binding and b.
+func (b ConvertedBinding[From, To]) Converter() Converter[From, To] {
+	return b.converter
+}
+
+func (b *ConvertedBinding[From, To]) Set(value To) {
+	b.mu.Lock()
+	if value == b.value {
+		b.mu.Unlock()
+		return
+	}
+	from, err := b.converter.ConvertBack(value)
+	b.value = value
+	b.err = err
+	b.mu.Unlock()
+
+	if err == nil {
+		b.source.Set(from)
+	}
+	b.notify(b)
+}
+
+// BindingChanged updates the value when the source binding changed.
+func (b *ConvertedBinding[From, To]) BindingChanged(Bindable) {
+	from := b.source.Get()
+
+	b.mu.Lock()
+	// Keep the current value when it already represents the source value,
+	// e.g. "1.50" for 1.5, so input is not rewritten while the user types.
+	if back, err := b.converter.ConvertBack(b.value); err == nil && back == from {
+		b.mu.Unlock()
+		return
+	}
+	value, err := b.converter.Convert(from)
+	if value == b.value && err == b.err {
+		b.mu.Unlock()
+		return
+	}
+	b.value = value
+	b.err = err
+	b.mu.Unlock()
+	b.notify(b)
+}
+
+// IntToString converts an int binding to a string, e.g. for an Input.
+func IntToString() *FuncConverter[int, string] {
+	return NewConverter(
+		func(v int) (string, error) {
+			return strconv.Itoa(v), nil
+		},
+		func(s string) (int, error) {
+			return strconv.Atoi(s)
+		},
+	)
+}
+
+// FloatToString converts a float64 binding to a string, e.g. for an Input.
+func FloatToString() *FuncConverter[float64, string] {
+	return NewConverter(
+		func(v float64) (string, error) {
+			return strconv.FormatFloat(v, 'f', -1, 64), nil
+		},
+		func(s string) (float64, error) {
+			return strconv.ParseFloat(s, 64)
+		},
+	)
+}
+
+// DurationToString converts a time.Duration binding to a string in the
+// format of time.ParseDuration (e.g. "1m30s").
+func DurationToString() *FuncConverter[time.Duration, string] {
+	return NewConverter(
+		func(v time.Duration) (string, error) {
+			return v.String(), nil
+		},
+		time.ParseDuration,
+	)
+}
+
+// Float64ToFloat32 converts a float64 binding to a float32, e.g. for a
+// Slider or a ProgressBar.
+func Float64ToFloat32() *FuncConverter[float64, float32] {
+	return NewConverter(
+		func(v float64) (float32, error) {
+			return float32(v), nil
+		},
+		func(v float32) (float64, error) {
+			return float64(v), nil
+		},
+	)
+}
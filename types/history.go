@@ -0,0 +1,278 @@
+// SPDX-License-Identifier: MIT
+
+package types
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultCoalesceWindow is the time within which consecutive changes of the
+// same binding are merged into a single undo step.
+const DefaultCoalesceWindow = time.Second
+
+// historyValue is implemented by the bindings that can be attached to a
+// History.
+type historyValue interface {
+	Bindable
+	anyValue() any
+	setAnyValue(any)
+}
+
+type historyChange struct {
+	binding historyValue
+	old     any
+	new     any
+}
+
+type historyStep struct {
+	changes []historyChange
+	time    time.Time
+	sealed  bool // Undone or redone, so never extended
+}
+
+// History records the changes of a set of bindings, so they can be undone
+// and redone.
+//
+// Every change of an attached binding is recorded as an undo step. Changes
+// made between Begin and Commit (or inside Transaction) form a single step.
+// Consecutive changes of the same binding within the coalesce window (e.g.
+// the keystrokes typed in an Input) are merged into one step as well.
+type History struct {
+	mu sync.Mutex
+
+	bindings       map[historyValue]any // Last known value of each binding
+	undo           []*historyStep
+	redo           []*historyStep
+	transaction    *historyStep
+	depth          int
+	coalesceWindow time.Duration
+
+	canUndo *Binding[bool]
+	canRedo *Binding[bool]
+}
+
+// NewHistory creates a new History. The CanUndo and CanRedo bindings are
+// named after the history (e.g. "<name> CanUndo").
+func NewHistory(name string) *History {
+	return &History{
+		bindings:       make(map[historyValue]any),
+		coalesceWindow: DefaultCoalesceWindow,
+		canUndo:        NewBinding(name+" CanUndo", false),
+		canRedo:        NewBinding(name+" CanRedo", false),
+	}
+}
+
+// Attach starts recording the changes of the given bindings. Only the value
+// bindings, list bindings and map bindings of this package can be attached.
+func (h *History) Attach(bindings ...Bindable) error {
+	for _, b := range bindings {
+		hv, ok := b.(historyValue)
+		if !ok {
+			return fmt.Errorf("binding %s does not support history", b.Name())
+		}
+		h.mu.Lock()
+		h.bindings[hv] = hv.anyValue()
+		h.mu.Unlock()
+		hv.Watch(h)
+	}
+	return nil
+}
+
+// Detach stops recording the changes of the given bindings. Recorded changes
+// of these bindings are kept.
+func (h *History) Detach(bindings ...Bindable) {
+	for _, b := range bindings {
+		if hv, ok := b.(historyValue); ok {
+			hv.Unwatch(h)
+			h.mu.Lock()
+			delete(h.bindings, hv)
+			h.mu.Unlock()
+		}
+	}
+}
+
+// SetCoalesceWindow sets the time within which consecutive changes of the
+// same binding are merged. A window of 0 disables coalescing.
+func (h *History) SetCoalesceWindow(window time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.coalesceWindow = window
+}
+
+// CanUndo returns a binding that is true while there are steps to undo.
+func (h *History) CanUndo() *Binding[bool] {
+	return h.canUndo
+}
+
+// CanRedo returns a binding that is true while there are steps to redo.
+func (h *History) CanRedo() *Binding[bool] {
+	return h.canRedo
+}
+
+// Begin starts a transaction. All changes until the matching Commit are
+// undone and redone as a single step. Transactions can be nested, only the
+// outer most transaction creates a step.
+func (h *History) Begin() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.depth == 0 {
+		h.transaction = &historyStep{}
+	}
+	h.depth++
+}
+
+// Commit ends a transaction started by Begin.
+func (h *History) Commit() error {
+	h.mu.Lock()
+	if h.depth == 0 {
+		h.mu.Unlock()
+		return errors.New("no transaction to commit")
+	}
+	h.depth--
+	if h.depth == 0 {
+		if len(h.transaction.changes) > 0 {
+			h.transaction.time = time.Now()
+			h.push(h.transaction)
+		}
+		h.transaction = nil
+	}
+	h.mu.Unlock()
+
+	h.updateBindings()
+	return nil
+}
+
+// Transaction runs fn inside a transaction.
+func (h *History) Transaction(fn func()) {
+	h.Begin()
+	defer h.Commit()
+	fn()
+}
+
+// Undo reverts the last step. It returns false if there is nothing to undo.
+func (h *History) Undo() bool {
+	h.mu.Lock()
+	if len(h.undo) == 0 || h.depth > 0 {
+		h.mu.Unlock()
+		return false
+	}
+	step := h.undo[len(h.undo)-1]
+	step.sealed = true
+	h.undo = h.undo[:len(h.undo)-1]
+	h.redo = append(h.redo, step)
+	for i := len(step.changes) - 1; i >= 0; i-- {
+		h.bindings[step.changes[i].binding] = step.changes[i].old
+	}
+	h.mu.Unlock()
+
+	for i := len(step.changes) - 1; i >= 0; i-- {
+		step.changes[i].binding.setAnyValue(step.changes[i].old)
+	}
+	h.updateBindings()
+	return true
+}
+
+// Redo reapplies the last undone step. It returns false if there is nothing
+// to redo.
+func (h *History) Redo() bool {
+	h.mu.Lock()
+	if len(h.redo) == 0 || h.depth > 0 {
+		h.mu.Unlock()
+		return false
+	}
+	step := h.redo[len(h.redo)-1]
+	step.sealed = true
+	h.redo = h.redo[:len(h.redo)-1]
+	h.undo = append(h.undo, step)
+	for _, c := range step.changes {
+		h.bindings[c.binding] = c.new
+	}
+	h.mu.Unlock()
+
+	for _, c := range step.changes {
+		c.binding.setAnyValue(c.new)
+	}
+	h.updateBindings()
+	return true
+}
+
+// Clear removes all recorded steps.
+func (h *History) Clear() {
+	h.mu.Lock()
+	h.undo = nil
+	h.redo = nil
+	h.mu.Unlock()
+	h.updateBindings()
+}
+
+// BindingChanged records the change of an attached binding.
+func (h *History) BindingChanged(binding Bindable) {
+	hv, ok := binding.(historyValue)
+	if !ok {
+		return
+	}
+
+	h.mu.Lock()
+	old, ok := h.bindings[hv]
+	value := hv.anyValue()
+	if !ok || equalValue(old, value) {
+		// Not attached, or the change was made by Undo or Redo
+		h.mu.Unlock()
+		return
+	}
+	h.bindings[hv] = value
+	change := historyChange{binding: hv, old: old, new: value}
+
+	if h.transaction != nil {
+		h.transaction.changes = append(h.transaction.changes, change)
+		h.mu.Unlock()
+		return
+	}
+
+	now := time.Now()
+	if last := h.lastStep(); last != nil &&
+		len(last.changes) == 1 &&
+		last.changes[0].binding == hv &&
+		now.Sub(last.time) < h.coalesceWindow {
+		last.changes[0].new = value
+		last.time = now
+	} else {
+		h.push(&historyStep{changes: []historyChange{change}, time: now})
+	}
+	h.mu.Unlock()
+
+	h.updateBindings()
+}
+
+// push adds a step to the undo stack. Must be called with the lock held.
+func (h *History) push(step *historyStep) {
+	h.undo = append(h.undo, step)
+	h.redo = nil
+}
+
+// lastStep returns the step that can be coalesced with a new change. Must be
+// called with the lock held.
+func (h *History) lastStep() *historyStep {
+	// A step that was undone and redone is never extended, nor is a step
+	// below an undone step
+	if len(h.undo) == 0 || len(h.redo) > 0 {
+		return nil
+	}
+	if last := h.undo[len(h.undo)-1]; !last.sealed {
+		return last
+	}
+	return nil
+}
+
+func (h *History) updateBindings() {
+	h.mu.Lock()
+	canUndo := len(h.undo) > 0
+	canRedo := len(h.redo) > 0
+	h.mu.Unlock()
+
+	h.canUndo.Set(canUndo)
+	h.canRedo.Set(canRedo)
+}
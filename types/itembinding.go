@@ -0,0 +1,316 @@
+// SPDX-License-Identifier: MIT
+
+package types
+
+import (
+	"reflect"
+	"strings"
+)
+
+// itemReplacer is implemented by list bindings that can replace an item with
+// a value of any type, so ItemFieldBinding.Set can write back to the list.
+type itemReplacer interface {
+	replaceAny(index int, value any) bool
+}
+
+// itemField is a binding that depends on an ItemBinding.
+type itemField interface {
+	Bindable
+	itemChanged()
+}
+
+// ItemBinding is a binding for the item at an index of a BindableList. It is
+// the binding context of the elements created from an item template.
+//
+// The fields of the item are looked up by Lookup with a dot separated path,
+// e.g. "Name" or "Address.City". The empty path is the item itself. Struct
+// fields must be exported; items that are maps with string keys are looked up
+// by key.
+//
+// The owner of the ItemBinding (e.g. widget.List) keeps the index in sync
+// with the list and calls Changed when the item at the index changed.
+type ItemBinding struct {
+	*binding
+	list   BindableList
+	index  int
+	fields map[string]itemField
+}
+
+func NewItemBinding(list BindableList, index int) *ItemBinding {
+	return &ItemBinding{
+		binding: newBinding(list.Name() + " Item"),
+		list:    list,
+		index:   index,
+		fields:  make(map[string]itemField),
+	}
+}
+
+func (b *ItemBinding) List() BindableList {
+	return b.list
+}
+
+func (b *ItemBinding) Index() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.index
+}
+
+// SetIndex moves the binding to another index. Watchers are not notified, as
+// the item itself did not change; call Changed when it did.
+func (b *ItemBinding) SetIndex(index int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.index = index
+}
+
+// Get returns the item, or nil if the index is out of range.
+func (b *ItemBinding) Get() any {
+	value, ok := b.list.GetAt(b.Index())
+	if !ok {
+		return nil
+	}
+	return value
+}
+
+// Changed informs the watchers of the item and of its fields that the item
+// changed.
+func (b *ItemBinding) Changed() {
+	b.mu.RLock()
+	fields := make([]itemField, 0, len(b.fields))
+	for _, field := range b.fields {
+		fields = append(fields, field)
+	}
+	b.mu.RUnlock()
+
+	b.notify(b)
+	for _, field := range fields {
+		field.itemChanged()
+	}
+}
+
+// Lookup returns the binding for the field at path of the item. The binding
+// is an *ItemFieldBinding of the type of the field: string, bool, int, int64,
+// float32 or float64 for fields of those kinds, and any for other fields.
+func (b *ItemBinding) Lookup(path string) (Bindable, bool) {
+	b.mu.RLock()
+	field, ok := b.fields[path]
+	b.mu.RUnlock()
+	if ok {
+		return field, true
+	}
+
+	var names []string
+	if path != "" {
+		names = strings.Split(path, ".")
+	}
+	value, ok := fieldValue(reflect.ValueOf(b.Get()), names)
+	if !ok {
+		return nil, false
+	}
+
+	name := b.name + " " + path
+	switch value.Kind() {
+	case reflect.String:
+		field = newItemFieldBinding[string](name, b, names)
+	case reflect.Bool:
+		field = newItemFieldBinding[bool](name, b, names)
+	case reflect.Int:
+		field = newItemFieldBinding[int](name, b, names)
+	case reflect.Int64:
+		field = newItemFieldBinding[int64](name, b, names)
+	case reflect.Float32:
+		field = newItemFieldBinding[float32](name, b, names)
+	case reflect.Float64:
+		field = newItemFieldBinding[float64](name, b, names)
+	default:
+		field = newItemFieldBinding[any](name, b, names)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if existing, ok := b.fields[path]; ok {
+		return existing, true
+	}
+	b.fields[path] = field
+	return field, true
+}
+
+// setField sets the field at path of the item and replaces the item in the
+// list. It does nothing if the list can not be written to or the field
+// already holds the value.
+func (b *ItemBinding) setField(path []string, value reflect.Value) {
+	replacer, ok := b.list.(itemReplacer)
+	if !ok {
+		return
+	}
+	index := b.Index()
+	item, ok := b.list.GetAt(index)
+	if !ok {
+		return
+	}
+
+	current := reflect.ValueOf(item)
+	if !current.IsValid() {
+		return
+	}
+	if old, ok := fieldValue(current, path); ok {
+		if nv := indirect(value); nv.IsValid() && nv.Type().ConvertibleTo(old.Type()) &&
+			equalValue(old.Interface(), nv.Convert(old.Type()).Interface()) {
+			// Unchanged, so there is nothing to replace
+			return
+		}
+	}
+
+	// Work on a copy, so the item in the list is only changed by the replace
+	cpy, ok := withFieldValue(current, path, value)
+	if !ok {
+		return
+	}
+	replacer.replaceAny(index, cpy.Interface())
+}
+
+// ItemFieldBinding is a binding for a field of the item of an ItemBinding
+// (see ItemBinding.Lookup). Setting the value replaces the item in the list.
+type ItemFieldBinding[T any] struct {
+	*binding
+	item *ItemBinding
+	path []string
+}
+
+func newItemFieldBinding[T any](
+	name string,
+	item *ItemBinding,
+	path []string,
+) *ItemFieldBinding[T] {
+	return &ItemFieldBinding[T]{
+		binding: newBinding(name),
+		item:    item,
+		path:    path,
+	}
+}
+
+// Get returns the value of the field, or the zero value if the item has no
+// such field (anymore).
+func (b *ItemFieldBinding[T]) Get() (res T) {
+	value, ok := fieldValue(reflect.ValueOf(b.item.Get()), b.path)
+	if !ok {
+		return
+	}
+	target := reflect.TypeFor[T]()
+	if !value.Type().ConvertibleTo(target) {
+		return
+	}
+	return value.Convert(target).Interface().(T)
+}
+
+func (b *ItemFieldBinding[T]) Set(value T) {
+	b.item.setField(b.path, reflect.ValueOf(&value).Elem())
+}
+
+func (b *ItemFieldBinding[T]) itemChanged() {
+	b.notify(b)
+}
+
+// fieldValue returns the value at path in v.
+func fieldValue(v reflect.Value, path []string) (reflect.Value, bool) {
+	for _, name := range path {
+		v = indirect(v)
+		switch v.Kind() {
+		case reflect.Struct:
+			field, ok := v.Type().FieldByName(name)
+			if !ok || !field.IsExported() {
+				return reflect.Value{}, false
+			}
+			v = v.FieldByIndex(field.Index)
+		case reflect.Map:
+			if v.Type().Key().Kind() != reflect.String {
+				return reflect.Value{}, false
+			}
+			v = v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key()))
+		default:
+			return reflect.Value{}, false
+		}
+	}
+	v = indirect(v)
+	return v, v.IsValid()
+}
+
+// withFieldValue returns a copy of v with the value at path set to value.
+// The pointers and maps on the path are copied as well, so v and the values
+// it shares with other items are left untouched.
+func withFieldValue(v reflect.Value, path []string, value reflect.Value) (reflect.Value, bool) {
+	if len(path) == 0 {
+		if !value.Type().ConvertibleTo(v.Type()) {
+			return reflect.Value{}, false
+		}
+		return value.Convert(v.Type()), true
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		elem, ok := withFieldValue(v.Elem(), path, value)
+		if !ok {
+			return reflect.Value{}, false
+		}
+		cpy := reflect.New(v.Type().Elem())
+		cpy.Elem().Set(elem)
+		return cpy, true
+	case reflect.Interface:
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		elem, ok := withFieldValue(v.Elem(), path, value)
+		if !ok {
+			return reflect.Value{}, false
+		}
+		cpy := reflect.New(v.Type()).Elem()
+		cpy.Set(elem)
+		return cpy, true
+	case reflect.Struct:
+		field, ok := v.Type().FieldByName(path[0])
+		if !ok || !field.IsExported() {
+			return reflect.Value{}, false
+		}
+		elem, ok := withFieldValue(v.FieldByIndex(field.Index), path[1:], value)
+		if !ok {
+			return reflect.Value{}, false
+		}
+		cpy := reflect.New(v.Type()).Elem()
+		cpy.Set(v)
+		cpy.FieldByIndex(field.Index).Set(elem)
+		return cpy, true
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return reflect.Value{}, false
+		}
+		key := reflect.ValueOf(path[0]).Convert(v.Type().Key())
+		elem := v.MapIndex(key)
+		if !elem.IsValid() {
+			elem = reflect.Zero(v.Type().Elem())
+		}
+		elem, ok := withFieldValue(elem, path[1:], value)
+		if !ok {
+			return reflect.Value{}, false
+		}
+		cpy := reflect.MakeMapWithSize(v.Type(), v.Len()+1)
+		for iter := v.MapRange(); iter.Next(); {
+			cpy.SetMapIndex(iter.Key(), iter.Value())
+		}
+		cpy.SetMapIndex(key, elem)
+		return cpy, true
+	}
+	return reflect.Value{}, false
+}
+
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
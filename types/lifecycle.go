@@ -0,0 +1,18 @@
+// SPDX-License-Identifier: MIT
+
+package types
+
+// Disposable is implemented by UI elements that hold on to resources, like
+// the bindings they watch. Dispose is called when the element is discarded,
+// e.g. when a definition is reloaded.
+type Disposable interface {
+	Dispose()
+}
+
+// StatefulElement is implemented by UI elements that keep state which is not
+// stored in a binding (e.g. the text of an unbound input). When a definition
+// is reloaded, TransferState is called on the new element with the old
+// element that had the same id.
+type StatefulElement interface {
+	TransferState(old UIElement)
+}
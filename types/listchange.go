@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: MIT
+
+package types
+
+import (
+	"slices"
+	"sync/atomic"
+)
+
+type ListChangeKind uint8
+
+const (
+	ListReset   ListChangeKind = iota // The whole list was replaced
+	ListInsert                        // Count items were inserted at Index
+	ListRemove                        // Count items were removed at Index
+	ListMove                          // The item at Index was moved to To
+	ListReplace                       // Count items starting at Index were replaced
+)
+
+func (k ListChangeKind) String() string {
+	return [...]string{
+		"Reset",
+		"Insert",
+		"Remove",
+		"Move",
+		"Replace"}[k]
+}
+
+// ListChange describes a single change of a BindableList.
+type ListChange struct {
+	Kind  ListChangeKind
+	Index int // First index affected by the change
+	Count int // Number of items affected by the change
+	To    int // Destination index of a ListMove
+}
+
+// ListBindingWatcher is a BindingWatcher that wants to be informed about the
+// individual changes of a BindableList, instead of only being told the list
+// changed.
+//
+// Watchers implementing this interface receive ListBindingChanged instead of
+// BindingChanged for every change of a ListBinding or StructListBinding.
+type ListBindingWatcher interface {
+	BindingWatcher
+	ListBindingChanged(BindableList, ListChange)
+}
+
+// The helpers below change the given slice in place, so a change of a single
+// item does not copy the whole list. Lists that were handed out by Get are
+// copied first, see ownList.
+
+// ownList returns list, or a copy of it when it was handed out to a reader
+// (shared is set). Must be called with the lock of the binding held.
+func ownList[T any](list []T, shared *atomic.Bool) []T {
+	if shared.Swap(false) {
+		return slices.Clone(list)
+	}
+	return list
+}
+
+func insertValues[T any](list []T, index int, values []T) ([]T, bool) {
+	if index < 0 || index > len(list) {
+		return list, false
+	}
+	return slices.Insert(list, index, values...), true
+}
+
+func removeValues[T any](list []T, index int, count int) ([]T, bool) {
+	if count < 1 || index < 0 || index+count > len(list) {
+		return list, false
+	}
+	return slices.Delete(list, index, index+count), true
+}
+
+func moveValue[T any](list []T, from int, to int) ([]T, bool) {
+	if from < 0 || from >= len(list) || to < 0 || to >= len(list) {
+		return list, false
+	}
+	value := list[from]
+	if from < to {
+		copy(list[from:to], list[from+1:to+1])
+	} else {
+		copy(list[to+1:from+1], list[to:from])
+	}
+	list[to] = value
+	return list, true
+}
+
+func replaceValues[T any](list []T, index int, values []T) ([]T, bool) {
+	if index < 0 || index+len(values) > len(list) {
+		return list, false
+	}
+	copy(list[index:], values)
+	return list, true
+}
+
+// sameValues returns whether the items of list starting at index equal
+// values, so replacing them changes nothing.
+func sameValues[T any](list []T, index int, values []T, equal func(a, b T) bool) bool {
+	if index < 0 || index+len(values) > len(list) {
+		return false
+	}
+	for i, v := range values {
+		if !equal(list[index+i], v) {
+			return false
+		}
+	}
+	return true
+}
+
+// countArg returns the optional count argument, defaulting to 1.
+func countArg(count []int) int {
+	if len(count) == 0 {
+		return 1
+	}
+	return count[0]
+}
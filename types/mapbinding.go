@@ -0,0 +1,295 @@
+// SPDX-License-Identifier: MIT
+
+package types
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+)
+
+type MapChangeKind uint8
+
+const (
+	MapReset  MapChangeKind = iota // The whole map was replaced
+	MapAdd                         // Key was added
+	MapUpdate                      // The value of Key changed
+	MapDelete                      // Key was deleted
+)
+
+func (k MapChangeKind) String() string {
+	return [...]string{
+		"Reset",
+		"Add",
+		"Update",
+		"Delete"}[k]
+}
+
+// MapChange describes a single change of a BindableMap.
+type MapChange struct {
+	Kind MapChangeKind
+	Key  any // The key that changed (nil for MapReset)
+}
+
+// MapBindingWatcher is a BindingWatcher that wants to be informed about the
+// individual key changes of a BindableMap.
+//
+// Watchers implementing this interface receive MapBindingChanged instead of
+// BindingChanged for every change of a MapBinding.
+type MapBindingWatcher interface {
+	BindingWatcher
+	MapBindingChanged(BindableMap, MapChange)
+}
+
+// KeyedBindable is a binding that contains other bindings that can be looked
+// up by a key. Definitions use it to resolve references like
+// `binding: Settings[theme]`.
+type KeyedBindable interface {
+	Bindable
+	Lookup(key string) (Bindable, bool)
+}
+
+type BindableMap interface {
+	KeyedBindable
+	Size() int
+}
+
+// MapBinding is an observable map.
+type MapBinding[K comparable, V any] struct {
+	*binding
+	values  map[K]V
+	entries map[K]*MapEntryBinding[K, V]
+}
+
+func NewMapBinding[K comparable, V any](name string, values map[K]V) *MapBinding[K, V] {
+	return &MapBinding[K, V]{
+		binding: newBinding(name),
+		values:  maps.Clone(values),
+		entries: make(map[K]*MapEntryBinding[K, V]),
+	}
+}
+
+// Init initializes a zero value MapBinding with the given name.
+func (b *MapBinding[K, V]) Init(name string) {
+	if b.binding == nil {
+		b.binding = newBinding(name)
+	}
+	if b.entries == nil {
+		b.entries = make(map[K]*MapEntryBinding[K, V])
+	}
+}
+
+func (b MapBinding[K, V]) anyValue() any {
+	return b.Get()
+}
+
+func (b *MapBinding[K, V]) setAnyValue(value any) {
+	b.Set(value.(map[K]V))
+}
+
+// Get returns a copy of the map.
+func (b MapBinding[K, V]) Get() map[K]V {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return maps.Clone(b.values)
+}
+
+// GetKey returns the value stored for key.
+func (b MapBinding[K, V]) GetKey(key K) (value V, ok bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	value, ok = b.values[key]
+	return
+}
+
+// Keys returns the keys of the map in no particular order.
+func (b MapBinding[K, V]) Keys() []K {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	keys := make([]K, 0, len(b.values))
+	for k := range b.values {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func (b MapBinding[K, V]) Size() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.values)
+}
+
+// Set replaces the whole map.
+func (b *MapBinding[K, V]) Set(values map[K]V) {
+	b.mu.Lock()
+	b.values = maps.Clone(values)
+	b.mu.Unlock()
+	b.notifyMap(MapChange{Kind: MapReset})
+}
+
+// SetKey adds or updates the value for key. Watchers are not notified when
+// the value did not change.
+func (b *MapBinding[K, V]) SetKey(key K, value V) {
+	b.mu.Lock()
+	old, exists := b.values[key]
+	if exists && equalValue(old, value) {
+		b.mu.Unlock()
+		return
+	}
+	if b.values == nil {
+		b.values = make(map[K]V)
+	}
+	b.values[key] = value
+	b.mu.Unlock()
+
+	if exists {
+		b.notifyMap(MapChange{Kind: MapUpdate, Key: key})
+	} else {
+		b.notifyMap(MapChange{Kind: MapAdd, Key: key})
+	}
+}
+
+// Delete removes key from the map.
+func (b *MapBinding[K, V]) Delete(key K) {
+	b.mu.Lock()
+	if _, ok := b.values[key]; !ok {
+		b.mu.Unlock()
+		return
+	}
+	delete(b.values, key)
+	b.mu.Unlock()
+	b.notifyMap(MapChange{Kind: MapDelete, Key: key})
+}
+
+// Entry returns a binding for the value of a single key.
+func (b *MapBinding[K, V]) Entry(key K) *MapEntryBinding[K, V] {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if e, ok := b.entries[key]; ok {
+		return e
+	}
+	e := &MapEntryBinding[K, V]{
+		binding: newBinding(fmt.Sprintf("%s[%v]", b.name, key)),
+		parent:  b,
+		key:     key,
+	}
+	b.entries[key] = e
+	return e
+}
+
+// Lookup returns the entry binding for the key given as string.
+func (b *MapBinding[K, V]) Lookup(key string) (Bindable, bool) {
+	k, ok := parseKey[K](key)
+	if !ok {
+		return nil, false
+	}
+	return b.Entry(k), true
+}
+
+// notifyMap informs all watchers about a change of the map. When several
+// changes are merged by a Batch, MapBindingWatchers receive a single MapReset.
+func (b *MapBinding[K, V]) notifyMap(change MapChange) {
+	deliver := func(merged bool, d dispatchFunc) {
+		change := change
+		if merged {
+			change = MapChange{Kind: MapReset}
+		}
+		for _, w := range b.watcherList() {
+			if mw, ok := w.(MapBindingWatcher); ok {
+				d(w, func() {
+					mw.MapBindingChanged(b, change)
+				})
+				continue
+			}
+			d(w, func() {
+				w.BindingChanged(b)
+			})
+		}
+	}
+	if !b.binding.deferNotification(deliver) {
+		deliver(false, dispatch)
+	}
+}
+
+// MapEntryBinding is a binding for the value of a single key of a MapBinding.
+//
+// Setting the value of a missing key adds it to the map. Watchers are only
+// notified about changes of their own key.
+type MapEntryBinding[K comparable, V any] struct {
+	*binding
+	parent *MapBinding[K, V]
+	key    K
+}
+
+func (b MapEntryBinding[K, V]) Key() K {
+	return b.key
+}
+
+// Get returns the value of the entry, or the zero value if the key is not in
+// the map.
+func (b MapEntryBinding[K, V]) Get() V {
+	v, _ := b.parent.GetKey(b.key)
+	return v
+}
+
+func (b *MapEntryBinding[K, V]) Set(value V) {
+	b.parent.SetKey(b.key, value)
+}
+
+// Watch adds a watcher. The parent map is only watched as long as the entry
+// has watchers itself.
+func (b *MapEntryBinding[K, V]) Watch(watcher BindingWatcher) {
+	b.mu.Lock()
+	first := len(b.watchers) == 0
+	b.watchers[watcher] = struct{}{}
+	b.mu.Unlock()
+
+	if first {
+		b.parent.Watch(b)
+	}
+}
+
+func (b *MapEntryBinding[K, V]) Unwatch(watcher BindingWatcher) {
+	b.mu.Lock()
+	delete(b.watchers, watcher)
+	last := len(b.watchers) == 0
+	b.mu.Unlock()
+
+	if last {
+		b.parent.Unwatch(b)
+	}
+}
+
+func (b *MapEntryBinding[K, V]) BindingChanged(Bindable) {
+	b.notify(b)
+}
+
+func (b *MapEntryBinding[K, V]) MapBindingChanged(_ BindableMap, change MapChange) {
+	if change.Kind == MapReset || change.Key == any(b.key) {
+		b.notify(b)
+	}
+}
+
+// parseKey converts a key from a definition to the key type of a map.
+func parseKey[K comparable](s string) (key K, ok bool) {
+	v := reflect.ValueOf(&key).Elem()
+	if v.Kind() == reflect.String {
+		v.SetString(s)
+		return key, true
+	}
+	if _, err := fmt.Sscan(s, &key); err != nil {
+		return key, false
+	}
+	return key, true
+}
+
+// equalValue compares two values of any type, falling back to a deep
+// comparison for values that can not be compared with ==.
+func equalValue[V any](a, b V) (equal bool) {
+	defer func() {
+		if recover() != nil {
+			equal = reflect.DeepEqual(a, b)
+		}
+	}()
+	return any(a) == any(b)
+}
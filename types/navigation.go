@@ -0,0 +1,28 @@
+// SPDX-License-Identifier: MIT
+
+package types
+
+// RouteParams are the parameters a view is navigated to with, e.g. the id of
+// the item a detail view shows. See goui.Navigator.
+type RouteParams map[string]string
+
+// Navigable is implemented by views that are notified when a navigator shows
+// them and when it navigates away from them.
+type Navigable interface {
+	// OnNavigatedTo is called when the view becomes the current view, after
+	// it is initialized or resumed, with the parameters of its route.
+	OnNavigatedTo(ctx Context, params RouteParams)
+
+	// OnNavigatedFrom is called when the view stops being the current view,
+	// before it is paused or destroyed.
+	OnNavigatedFrom(ctx Context)
+}
+
+// Pausable is implemented by views that are kept alive on the back-stack of a
+// navigator. OnPause is called when another view is pushed on top of the
+// view, OnResume when the view is shown again. Views that are not kept alive
+// are destroyed and initialized again instead.
+type Pausable interface {
+	OnPause(ctx Context)
+	OnResume(ctx Context)
+}
@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: MIT
+
+package types
+
+// NotBinding is a read-only binding holding the inverse of a boolean binding,
+// e.g. for `visible: !Boiling` in a definition.
+//
+// The source binding is only watched as long as the NotBinding has watchers
+// itself, so it can be dropped without being detached.
+type NotBinding struct {
+	*binding
+	source ValueBinding[bool]
+}
+
+// Not returns a binding holding the inverse of source. It is named after the
+// source with a "!" prefix.
+func Not(source ValueBinding[bool]) *NotBinding {
+	return &NotBinding{
+		binding: newBinding("!" + source.Name()),
+		source:  source,
+	}
+}
+
+func (b *NotBinding) Get() bool {
+	return !b.source.Get()
+}
+
+// Watch adds a watcher. The source is watched while there are watchers.
+func (b *NotBinding) Watch(watcher BindingWatcher) {
+	b.mu.Lock()
+	first := len(b.watchers) == 0
+	b.watchers[watcher] = struct{}{}
+	b.mu.Unlock()
+
+	if first {
+		b.source.Watch(b)
+	}
+}
+
+func (b *NotBinding) Unwatch(watcher BindingWatcher) {
+	b.mu.Lock()
+	delete(b.watchers, watcher)
+	last := len(b.watchers) == 0
+	b.mu.Unlock()
+
+	if last {
+		b.source.Unwatch(b)
+	}
+}
+
+func (b *NotBinding) BindingChanged(Bindable) {
+	b.notify(b)
+}
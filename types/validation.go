@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: MIT
+
+package types
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"regexp"
+	"unicode/utf8"
+)
+
+// Validator checks a value and returns an error describing why the value is
+// invalid, or nil if the value is valid. Any function with this signature can
+// be used as a custom validator.
+type Validator[T any] func(T) error
+
+// Validatable is implemented by bindings that support validators.
+type Validatable interface {
+	Bindable
+	ErrorReporter
+	Errors() []error
+	Valid() *Binding[bool]
+}
+
+// Required fails for the zero value of T.
+func Required[T comparable]() Validator[T] {
+	return func(v T) error {
+		var zero T
+		if v == zero {
+			return errors.New("value is required")
+		}
+		return nil
+	}
+}
+
+// Range fails for values outside of [min, max].
+func Range[T cmp.Ordered](min, max T) Validator[T] {
+	return func(v T) error {
+		if v < min || v > max {
+			return fmt.Errorf("value must be between %v and %v", min, max)
+		}
+		return nil
+	}
+}
+
+// Regex fails for strings that do not match the pattern. It panics if the
+// pattern does not compile.
+func Regex(pattern string) Validator[string] {
+	re := regexp.MustCompile(pattern)
+	return func(v string) error {
+		if !re.MatchString(v) {
+			return fmt.Errorf("value must match %s", pattern)
+		}
+		return nil
+	}
+}
+
+// Length fails for strings with less than min or more than max characters.
+// A max of 0 or less means there is no maximum.
+func Length(min, max int) Validator[string] {
+	return func(v string) error {
+		n := utf8.RuneCountInString(v)
+		if n < min {
+			return fmt.Errorf("value must be at least %d characters", min)
+		}
+		if max > 0 && n > max {
+			return fmt.Errorf("value must be at most %d characters", max)
+		}
+		return nil
+	}
+}
+
+// AllValid creates a binding that is true while all fields are valid, e.g. to
+// enable the submit button of a form.
+func AllValid(name string, fields ...Validatable) *ComputedBinding[bool] {
+	sources := make([]Bindable, 0, len(fields))
+	for _, f := range fields {
+		sources = append(sources, f.Valid())
+	}
+	return NewComputedBinding(name, func() bool {
+		for _, f := range fields {
+			if !f.Valid().Get() {
+				return false
+			}
+		}
+		return true
+	}, sources...)
+}
+
+func validate[T any](validators []Validator[T], value T) (errs []error) {
+	for _, v := range validators {
+		if err := v(value); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return
+}
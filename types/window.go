@@ -5,6 +5,8 @@ package types
 import "gioui.org/widget/material"
 
 type Window interface {
+	Dispatcher
+
 	Theme() *material.Theme
 	Invalidate()
 }
@@ -3,8 +3,9 @@
 package goui
 
 import (
-	"embed"
 	"fmt"
+	"io/fs"
+	"os"
 
 	giolayout "gioui.org/layout"
 
@@ -14,12 +15,18 @@ import (
 )
 
 type ViewScreen struct {
-	fs         embed.FS
+	fs         fs.FS
 	screenName string
+	devDir     string // Directory the definition is loaded from in dev mode
+	strict     bool   // Reject properties the elements do not declare
+
+	registry *definition.Registry // Elements of the definition, nil for the default
+
+	build func() definition.Node // Builds the definition in code
 }
 
 func NewViewScreen(
-	fs embed.FS,
+	fs fs.FS,
 	screenName string,
 ) *ViewScreen {
 	return &ViewScreen{
@@ -28,6 +35,76 @@ func NewViewScreen(
 	}
 }
 
+// NewBuiltViewScreen creates a screen whose definition is built in code by
+// build (see package def) instead of loaded from a definition file. The name
+// identifies the definition in errors.
+func NewBuiltViewScreen(
+	name string,
+	build func() definition.Node,
+) *ViewScreen {
+	return &ViewScreen{
+		screenName: name,
+		build:      build,
+	}
+}
+
+// EnableHotReload enables the dev mode for the screen.
+//
+// In dev mode the definition is loaded from the directory dir on disk instead
+// of from the filesystem passed to NewViewScreen (the screen name is relative
+// to dir). The file is watched for changes and the view is rebuilt at the
+// next frame when it changes. Errors in the definition are shown in the
+// window instead of stopping the application.
+//
+// It returns the screen, so it can be chained to NewViewScreen.
+func (s *ViewScreen) EnableHotReload(dir string) *ViewScreen {
+	s.devDir = dir
+	return s
+}
+
+// HotReload returns whether the dev mode is enabled. Screens built in code
+// (see NewBuiltViewScreen) are never reloaded.
+func (s ViewScreen) HotReload() bool {
+	return s.devDir != "" && s.build == nil
+}
+
+// EnableStrict makes loading the definition fail on properties that the
+// elements do not declare in their schema, which catches typos in property
+// names.
+//
+// It returns the screen, so it can be chained to NewViewScreen.
+func (s *ViewScreen) EnableStrict() *ViewScreen {
+	s.strict = true
+	return s
+}
+
+// UseRegistry creates the elements of the definition from registry instead
+// of the default registry (see definition.Registry).
+//
+// It returns the screen, so it can be chained to NewViewScreen.
+func (s *ViewScreen) UseRegistry(registry *definition.Registry) *ViewScreen {
+	s.registry = registry
+	return s
+}
+
+func (s ViewScreen) options() definition.Options {
+	return definition.Options{Strict: s.strict, Registry: s.registry}
+}
+
+func (s ViewScreen) load(ctx types.Context) (*definition.Definition, error) {
+	if s.build != nil {
+		return definition.BuildWithOptions(ctx, s.screenName, s.build(), s.options())
+	}
+	return definition.NewWithOptions(ctx, s.filesystem(), s.screenName, s.options())
+}
+
+func (s ViewScreen) filesystem() fs.FS {
+	if s.HotReload() {
+		return os.DirFS(s.devDir)
+	}
+	return s.fs
+}
+
 type View struct {
 	*widget.Widget
 
@@ -38,6 +115,10 @@ type View struct {
 	def  *definition.Definition
 	root types.UIElement
 
+	loadErr       error         // Definition error shown in dev mode
+	reloadPending bool          // Definition changed on disk
+	stopWatch     chan struct{} // Stops watching the definition file
+
 	exportedFns map[string]any
 }
 
@@ -63,10 +144,24 @@ func (v *View) ExportFunction(name string, fn any) {
 	v.exportedFns[name] = fn
 }
 
+// ExportCommand exports commands under their name, so they can be
+// referenced from the definition (e.g. `command: <name>` on a button).
+func (v *View) ExportCommand(commands ...types.Command) {
+	for _, c := range commands {
+		v.exportedFns[c.Name()] = c
+	}
+}
+
 func (v *View) SetViewRoot(root types.UIElement) {
 	v.root = root
 }
 
+// Root returns the root element of the view, nil until the view is
+// initialized.
+func (v *View) Root() types.UIElement {
+	return v.root
+}
+
 func (v *View) ViewModel() types.ViewModel {
 	return v.viewModel
 }
@@ -78,28 +173,58 @@ func (v *View) Initialize(ctx types.Context) (err error) {
 	v.viewModel.Initialize()
 
 	if v.viewScreen != nil {
-		v.def, err = definition.New(ctx, v.viewScreen.fs, v.viewScreen.screenName)
+		v.def, err = v.viewScreen.load(ctx)
 		if err != nil {
 			err = fmt.Errorf("failed to create definition: %w", err)
-			return err
+			if !v.viewScreen.HotReload() {
+				return err
+			}
+			// Show the error and wait for the definition to be fixed
+			v.loadErr = err
+			err = nil
+		} else {
+			v.root = v.def.Root()
+		}
+
+		if v.viewScreen.HotReload() {
+			v.startWatching(ctx.Window())
 		}
-		v.root = v.def.Root()
 	}
 
 	return
 }
 
 func (v *View) Destroy(ctx types.Context) (err error) {
+	v.stopWatching()
+	if v.def != nil {
+		v.def.Dispose()
+	}
 	err = v.viewModel.Destroy()
 	return
 }
 
 func (v *View) HandleEvents(ctx types.Context) {
-	v.root.HandleEvents(ctx)
+	if v.reloadPending {
+		v.reload(ctx)
+	}
+	if v.root != nil {
+		types.HandleElementEvents(ctx, v.root)
+	}
 }
 
 func (v *View) Draw(gtx giolayout.Context) giolayout.Dimensions {
-	return v.root.Draw(gtx)
+	if v.loadErr == nil {
+		return types.DrawElement(gtx, v.root)
+	}
+	if v.root == nil {
+		return v.drawLoadError(gtx)
+	}
+	return giolayout.Stack{}.Layout(gtx,
+		giolayout.Stacked(func(gtx giolayout.Context) giolayout.Dimensions {
+			return types.DrawElement(gtx, v.root)
+		}),
+		giolayout.Stacked(v.drawLoadError),
+	)
 }
 
 func (v *View) DrawView(ctx types.Context) giolayout.Dimensions {
@@ -113,10 +238,38 @@ func (v *View) FindFunction(name string) any {
 	return nil
 }
 
+// FunctionName returns the name fn is exported under, the reverse of
+// FindFunction.
+func (v *View) FunctionName(fn any) (string, bool) {
+	for name, exported := range v.exportedFns {
+		if definition.SameFunction(exported, fn) {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// Serialize writes the elements of the view as a YAML definition (see
+// definition.Serialize), with the names of the functions exported by the
+// view.
+func (v *View) Serialize() ([]byte, error) {
+	if v.root == nil {
+		return nil, fmt.Errorf("view has no elements")
+	}
+	options := definition.SerializeOptions{FunctionName: v.FunctionName}
+	if v.viewScreen != nil {
+		options.Registry = v.viewScreen.registry
+	}
+	return definition.Serialize(v.root, options)
+}
+
 func (v *View) FindBinding(name string) types.Bindable {
 	return v.viewModel.GetBinding(name)
 }
 
 func GetElementById[T any](v *View, id string) (elem T, ok bool) {
+	if v.def == nil {
+		return
+	}
 	return definition.ElementById[T](v.def, id)
 }
@@ -2,7 +2,13 @@
 
 package goui
 
-import "github.com/mheremans/goui/types"
+import (
+	"fmt"
+	"reflect"
+	"unsafe"
+
+	"github.com/mheremans/goui/types"
+)
 
 type ViewModel struct {
 	bindings map[string]types.Bindable
@@ -38,3 +44,92 @@ func (vm *ViewModel) RegisterBinding(binding types.Bindable) {
 	}
 	vm.bindings[binding.Name()] = binding
 }
+
+type bindingRegistrar interface {
+	RegisterBinding(types.Bindable)
+}
+
+var bindableType = reflect.TypeOf((*types.Bindable)(nil)).Elem()
+
+// AutoRegister registers all bindings stored in the fields of a view model.
+//
+// vm must be a pointer to a struct that embeds ViewModel (or otherwise has a
+// RegisterBinding method). Every field, exported or not, whose type
+// implements types.Bindable is registered. Fields that are nil are filled
+// with a new zero value binding, named after the `goui:"Name"` struct tag or
+// the field name. Bindings that are already set must have that name too.
+// Fields tagged with `goui:"-"` are skipped.
+//
+// An error is returned when vm has the wrong type, when a binding field is
+// not a pointer or an interface, when a nil binding can not be created
+// automatically (e.g. a types.ComputedBinding), when a binding that is
+// already set has another name or when two bindings share the same name.
+func AutoRegister(vm any) error {
+	registrar, ok := vm.(bindingRegistrar)
+	if !ok {
+		return fmt.Errorf("%T has no RegisterBinding method", vm)
+	}
+
+	rv := reflect.ValueOf(vm)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%T is not a pointer to a struct", vm)
+	}
+	rv = rv.Elem()
+	rt := rv.Type()
+
+	names := make(map[string]string)
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.Type.Implements(bindableType) {
+			continue
+		}
+
+		name, tagged := field.Tag.Lookup("goui")
+		if name == "-" {
+			continue
+		}
+		if !tagged || name == "" {
+			name = field.Name
+		}
+
+		// Value bindings (e.g. types.Binding[int]) implement Bindable through
+		// their embedded pointer, but would be registered as a copy
+		kind := field.Type.Kind()
+		if kind != reflect.Pointer && kind != reflect.Interface {
+			return fmt.Errorf(
+				"field %s: binding %s must be a pointer", field.Name, field.Type)
+		}
+
+		// Allow access to unexported fields
+		fv := rv.Field(i)
+		fv = reflect.NewAt(fv.Type(), unsafe.Pointer(fv.UnsafeAddr())).Elem()
+
+		if fv.IsNil() {
+			if kind != reflect.Pointer {
+				return fmt.Errorf("field %s: nil binding interface", field.Name)
+			}
+			bnd, ok := reflect.New(fv.Type().Elem()).Interface().(types.Initializable)
+			if !ok {
+				return fmt.Errorf(
+					"field %s: %s must be created by hand", field.Name, fv.Type())
+			}
+			bnd.Init(name)
+			fv.Set(reflect.ValueOf(bnd))
+		}
+
+		bnd := fv.Interface().(types.Bindable)
+		if bnd.Name() != name {
+			return fmt.Errorf(
+				"field %s: binding is named %q instead of %q",
+				field.Name, bnd.Name(), name)
+		}
+		if other, ok := names[bnd.Name()]; ok {
+			return fmt.Errorf(
+				"fields %s and %s: duplicate binding name %q",
+				other, field.Name, bnd.Name())
+		}
+		names[bnd.Name()] = field.Name
+		registrar.RegisterBinding(bnd)
+	}
+	return nil
+}
@@ -12,8 +12,23 @@ import (
 )
 
 func init() {
-	definition.RegisterUIElement((*Button)(nil), newButtonFromDefinition)
-	definition.RegisterUIElement((*IconButton)(nil), newIconButtonFromDefinition)
+	definition.RegisterUIElement((*Button)(nil), newButtonFromDefinition,
+		append(pointerEventProperties(),
+			definition.StringProperty("label").BindsTo("binding"),
+			definition.BindingProperty[types.ValueBinding[string]]("binding"),
+			definition.FunctionProperty[types.Command]("command"),
+			definition.FunctionProperty[OnClickedFn]("onClicked"),
+		)...,
+	)
+	definition.RegisterUIElement((*IconButton)(nil), newIconButtonFromDefinition,
+		append(pointerEventProperties(),
+			definition.StringProperty("icon"),
+			definition.StringProperty("description").BindsTo("descriptionBinding"),
+			definition.BindingProperty[types.ValueBinding[string]]("descriptionBinding"),
+			definition.FunctionProperty[types.Command]("command"),
+			definition.FunctionProperty[OnClickedFn]("onClicked"),
+		)...,
+	)
 }
 
 // Button is a clickable button
@@ -23,7 +38,21 @@ func init() {
 //	type: widget.Button
 //	id: <string>				# id of the element (used to get a reference to
 //								# it in code)
-//	label: <string>				# button label
+//	label: <string>				# button label, a binding expression like
+//								# "{Count} items" (see definition.IsExpression)
+//								# or a translated message like "@start" (see
+//								# definition.IsTranslation)
+//	binding: <string>			# label binding reference (will be requested
+//								# throught the view)
+//	enabled: <string>			# enabled binding reference (will be requested
+//								# throught the view), e.g. the types.AllValid
+//								# binding of a form. Prefix with ! to invert
+//	visible: <string>			# visible binding reference (will be requested
+//								# throught the view). Prefix with ! to invert
+//	command: <string>			# command reference (will be requested throught
+//								# the view), executed when the button is
+//								# clicked. The button is disabled while the
+//								# command can not be executed
 //	onClicked: <string>			# clicked the button (will be called when the
 //								# button is clicked)
 //	onHovered: <string>			# hovering over the button (will be called for
@@ -44,6 +73,9 @@ type Button struct {
 
 	button    *material.ButtonStyle
 	clickable giowidget.Clickable
+	binding   types.ValueBinding[string]
+
+	command types.Command
 
 	OnClicked      OnClickedFn
 	OnHovered      OnHoveredFn
@@ -87,9 +119,80 @@ func newButtonFromDefinition(
 	b.OnPressUp, _ = definition.FunctionFromMap[OnPressUpFn](
 		ctx, data, "onPressUp")
 
+	if binding, ok := definition.BindingFromMap[types.ValueBinding[string]](
+		ctx, data, "binding",
+	); ok {
+		b.Bind(binding)
+	}
+	if command, ok := definition.FunctionFromMap[types.Command](
+		ctx, data, "command",
+	); ok {
+		b.SetCommand(command)
+	}
+
 	return b, nil
 }
 
+// Properties returns the properties of the button as written in a definition
+// (see definition.Serializable).
+func (b *Button) Properties() map[string]any {
+	properties := map[string]any{
+		"binding":   b.binding,
+		"command":   b.command,
+		"onClicked": b.OnClicked,
+	}
+	if b.binding == nil {
+		properties["label"] = b.button.Text
+	}
+	return pointerEventValues(properties, b.OnHovered, b.OnHoverEntered,
+		b.OnHoverExited, b.OnPressed, b.OnPressDown, b.OnPressUp)
+}
+
+// Bind binds the label of the button to the given binding.
+func (b *Button) Bind(binding types.ValueBinding[string]) {
+	if b.binding != nil {
+		b.binding.Unwatch(b)
+		b.binding = nil
+	}
+
+	if binding == nil {
+		return
+	}
+
+	b.binding = binding
+	b.binding.Watch(b)
+	b.SetLabel(b.binding.Get())
+}
+
+// Enabled returns whether the button is enabled and its command (if any) can
+// be executed.
+func (b Button) Enabled() bool {
+	return b.Widget.Enabled() && canExecute(b.command)
+}
+
+func (b Button) Command() types.Command {
+	return b.command
+}
+
+// SetCommand sets the command executed when the button is clicked. The button
+// is disabled while the command can not be executed.
+func (b *Button) SetCommand(command types.Command) {
+	if b.command != nil {
+		b.command.CanExecute().Unwatch(b)
+	}
+	b.command = command
+	if b.command != nil {
+		b.command.CanExecute().Watch(b)
+	}
+	b.Wnd().Invalidate()
+}
+
+// Dispose stops watching the bindings and the command.
+func (b *Button) Dispose() {
+	b.Bind(nil)
+	b.SetCommand(nil)
+}
+
 func (b Button) Label() string {
 	return b.button.Text
 }
@@ -100,6 +203,12 @@ func (b *Button) SetLabel(label string) {
 }
 
 func (b *Button) HandleEvents(ctx types.Context) {
+	if !b.Enabled() {
+		b.prevPressState = false
+		b.prevHoverState = false
+		return
+	}
+
 	pressed := b.clickable.Pressed()
 	hovered := b.clickable.Hovered()
 	clicked := b.clickable.Clicked(ctx.Gtx())
@@ -126,12 +235,18 @@ func (b *Button) HandleEvents(ctx types.Context) {
 	}
 	b.prevHoverState = hovered
 
+	if clicked && b.command != nil {
+		b.command.Execute(ctx)
+	}
 	if b.OnClicked != nil && clicked {
 		b.OnClicked(ctx, b)
 	}
 }
 
 func (b *Button) Draw(gtx giolayout.Context) giolayout.Dimensions {
+	if !b.Enabled() {
+		gtx = gtx.Disabled()
+	}
 	return b.button.Layout(gtx)
 }
 
@@ -139,6 +254,19 @@ func (b *Button) AddChild(_ types.UIElement, _ ...float32) bool {
 	return false
 }
 
+func (b *Button) BindingChanged(binding types.Bindable) {
+	switch binding {
+	case b.binding:
+		if b.button.Text != b.binding.Get() {
+			b.SetLabel(b.binding.Get())
+		}
+	default:
+		if b.command != nil && binding == b.command.CanExecute() {
+			b.Wnd().Invalidate()
+		}
+	}
+}
+
 // Button is a clickable icon button
 //
 // Yaml	definition:
@@ -147,7 +275,15 @@ func (b *Button) AddChild(_ types.UIElement, _ ...float32) bool {
 //	id: <string>				# id of the element (used to get a reference to
 //								# it in code)
 //	icon: <string>				# button icon
-//	descriptoin: <string>		# button description
+//	description: <string>		# button description, a binding expression or
+//								# a translated message like "@start" (see
+//								# definition.IsTranslation)
+//	descriptionBinding: <string>	# description binding reference (will be
+//								# requested throught the view)
+//	command: <string>			# command reference (will be requested throught
+//								# the view), executed when the button is
+//								# clicked. The button is disabled while the
+//								# command can not be executed
 //	onClicked: <string>			# clicked the button (will be called when the
 //								# button is clicked)
 //	onHovered: <string>			# hovering over the button (will be called for
@@ -168,6 +304,10 @@ type IconButton struct {
 
 	button    *material.IconButtonStyle
 	clickable giowidget.Clickable
+	command   types.Command
+	icon      string
+
+	descriptionBinding types.ValueBinding[string]
 
 	OnClicked      OnClickedFn
 	OnHovered      OnHoveredFn
@@ -193,6 +333,7 @@ func NewIconButton(
 	b.Widget = NewWidget(ctx.Window(), id...)
 	b.wnd = ctx.Window()
 	b.button = &button
+	b.icon = icon
 	return b
 }
 
@@ -218,14 +359,61 @@ func newIconButtonFromDefinition(
 		ctx, data, "onPressDown")
 	b.OnPressUp, _ = definition.FunctionFromMap[OnPressUpFn](
 		ctx, data, "onPressUp")
+	if command, ok := definition.FunctionFromMap[types.Command](
+		ctx, data, "command",
+	); ok {
+		b.SetCommand(command)
+	}
+	if binding, ok := definition.BindingFromMap[types.ValueBinding[string]](
+		ctx, data, "descriptionBinding",
+	); ok {
+		b.BindDescription(binding)
+	}
 	return b, nil
 }
 
+// Properties returns the properties of the button as written in a definition
+// (see definition.Serializable).
+func (b *IconButton) Properties() map[string]any {
+	properties := map[string]any{
+		"icon":               b.icon,
+		"descriptionBinding": b.descriptionBinding,
+		"command":            b.command,
+		"onClicked":          b.OnClicked,
+	}
+	if b.descriptionBinding == nil {
+		properties["description"] = b.button.Description
+	}
+	return pointerEventValues(properties, b.OnHovered, b.OnHoverEntered,
+		b.OnHoverExited, b.OnPressed, b.OnPressDown, b.OnPressUp)
+}
+
+// BindDescription binds the description of the button to the given binding.
+func (b *IconButton) BindDescription(binding types.ValueBinding[string]) {
+	if b.descriptionBinding != nil {
+		b.descriptionBinding.Unwatch(b)
+		b.descriptionBinding = nil
+	}
+
+	if binding == nil {
+		return
+	}
+
+	b.descriptionBinding = binding
+	b.descriptionBinding.Watch(b)
+	b.SetDescription(b.descriptionBinding.Get())
+}
+
 func (b IconButton) Description() string {
 	return b.button.Description
 }
 
+func (b IconButton) Icon() string {
+	return b.icon
+}
+
 func (b *IconButton) SetIcon(icon string) {
+	b.icon = icon
 	b.button.Icon = icons.Icon(icon)
 	b.wnd.Invalidate()
 }
@@ -235,7 +423,42 @@ func (b *IconButton) SetDescription(description string) {
 	b.wnd.Invalidate()
 }
 
+// Enabled returns whether the button is enabled and its command (if any) can
+// be executed.
+func (b IconButton) Enabled() bool {
+	return b.Widget.Enabled() && canExecute(b.command)
+}
+
+func (b IconButton) Command() types.Command {
+	return b.command
+}
+
+// SetCommand sets the command executed when the button is clicked. The button
+// is disabled while the command can not be executed.
+func (b *IconButton) SetCommand(command types.Command) {
+	if b.command != nil {
+		b.command.CanExecute().Unwatch(b)
+	}
+	b.command = command
+	if b.command != nil {
+		b.command.CanExecute().Watch(b)
+	}
+	b.Wnd().Invalidate()
+}
+
+// Dispose stops watching the binding and the command.
+func (b *IconButton) Dispose() {
+	b.BindDescription(nil)
+	b.SetCommand(nil)
+}
+
 func (b *IconButton) HandleEvents(ctx types.Context) {
+	if !b.Enabled() {
+		b.prevPressState = false
+		b.prevHoverState = false
+		return
+	}
+
 	pressed := b.clickable.Pressed()
 	hovered := b.clickable.Hovered()
 	clicked := b.clickable.Clicked(ctx.Gtx())
@@ -262,11 +485,31 @@ func (b *IconButton) HandleEvents(ctx types.Context) {
 	}
 	b.prevHoverState = hovered
 
+	if clicked && b.command != nil {
+		b.command.Execute(ctx)
+	}
 	if b.OnClicked != nil && clicked {
 		b.OnClicked(ctx, b)
 	}
 }
 
 func (b *IconButton) Draw(gtx giolayout.Context) giolayout.Dimensions {
+	if !b.Enabled() {
+		gtx = gtx.Disabled()
+	}
 	return b.button.Layout(gtx)
 }
+
+func (b *IconButton) BindingChanged(binding types.Bindable) {
+	switch {
+	case b.descriptionBinding != nil && binding == b.descriptionBinding:
+		b.SetDescription(b.descriptionBinding.Get())
+	case b.command != nil && binding == b.command.CanExecute():
+		b.Wnd().Invalidate()
+	}
+}
+
+// canExecute returns whether the command is nil or can be executed.
+func canExecute(command types.Command) bool {
+	return command == nil || command.CanExecute().Get()
+}
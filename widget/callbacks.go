@@ -6,6 +6,7 @@ import (
 	"image"
 
 	giolayout "gioui.org/layout"
+	"github.com/mheremans/goui/definition"
 	"github.com/mheremans/goui/types"
 )
 
@@ -25,6 +26,39 @@ type OnPressedFn = func(types.Context, types.UIElement)
 type OnPressDownFn = func(types.Context, types.UIElement)
 type OnPressUpFn = func(types.Context, types.UIElement)
 
+// pointerEventProperties returns the definition properties of the pointer
+// event handlers shared by the clickable widgets.
+func pointerEventProperties() []definition.Property {
+	return []definition.Property{
+		definition.FunctionProperty[OnHoveredFn]("onHovered"),
+		definition.FunctionProperty[OnHoverEnteredFn]("onHoverEntered"),
+		definition.FunctionProperty[OnHoverExitedFn]("onHoverExited"),
+		definition.FunctionProperty[OnPressedFn]("onPressed"),
+		definition.FunctionProperty[OnPressDownFn]("onPressDown"),
+		definition.FunctionProperty[OnPressUpFn]("onPressUp"),
+	}
+}
+
+// pointerEventValues adds the pointer event handlers to the properties of a
+// clickable widget (see definition.Serializable).
+func pointerEventValues(
+	properties map[string]any,
+	onHovered OnHoveredFn,
+	onHoverEntered OnHoverEnteredFn,
+	onHoverExited OnHoverExitedFn,
+	onPressed OnPressedFn,
+	onPressDown OnPressDownFn,
+	onPressUp OnPressUpFn,
+) map[string]any {
+	properties["onHovered"] = onHovered
+	properties["onHoverEntered"] = onHoverEntered
+	properties["onHoverExited"] = onHoverExited
+	properties["onPressed"] = onPressed
+	properties["onPressDown"] = onPressDown
+	properties["onPressUp"] = onPressUp
+	return properties
+}
+
 // Validators
 
 type InputFilterFn = func(types.Context, types.UIElement, string) string
@@ -10,12 +10,29 @@ import (
 	"github.com/mheremans/goui/types"
 )
 
+func init() {
+	definition.RegisterUIElement((*CheckBox)(nil), newCheckBoxFromDefinition,
+		append(pointerEventProperties(),
+			definition.StringProperty("label").BindsTo("labelBinding"),
+			definition.BindingProperty[types.ValueBinding[string]]("labelBinding"),
+			definition.BoolProperty("value"),
+			definition.StringProperty("helperText"),
+			definition.BindingProperty[types.MutableBinding[bool]]("binding"),
+		)...,
+	)
+}
+
 type CheckBox struct {
 	*Widget
 
 	checkbox *material.CheckBoxStyle
 	check    giowidget.Bool
-	binding  *types.Binding[bool]
+	binding  types.MutableBinding[bool]
+	synced   bool // Value last read from or written to the binding
+
+	labelBinding types.ValueBinding[string]
+
+	helperText string
 
 	OnHovered      OnHoveredFn
 	OnHoverEntered OnHoverEnteredFn
@@ -49,10 +66,17 @@ func newCheckBoxFromDefinition(
 	id, _ := definition.MapValueString[string](data, "id")
 	label, _ := definition.MapValueString[string](data, "label")
 	value, _ := definition.MapValueBool[bool](data, "value")
+	helperText, _ := definition.MapValueString[string](data, "helperText")
 	c := NewCheckBox(ctx, label, value, id)
-	if binding, ok := definition.BindingFromMap[*types.Binding[bool]](ctx, data, "binding"); ok {
+	c.helperText = helperText
+	if binding, ok := definition.BindingFromMap[types.MutableBinding[bool]](ctx, data, "binding"); ok {
 		c.Bind(binding)
 	}
+	if binding, ok := definition.BindingFromMap[types.ValueBinding[string]](
+		ctx, data, "labelBinding",
+	); ok {
+		c.BindLabel(binding)
+	}
 	c.OnHovered, _ = definition.FunctionFromMap[OnHoveredFn](
 		ctx, data, "onHovered")
 	c.OnHoverEntered, _ = definition.FunctionFromMap[OnHoverEnteredFn](
@@ -69,7 +93,25 @@ func newCheckBoxFromDefinition(
 	return c, nil
 }
 
-func (c *CheckBox) Bind(binding *types.Binding[bool]) {
+// Properties returns the properties of the check box as written in a
+// definition (see definition.Serializable).
+func (c *CheckBox) Properties() map[string]any {
+	properties := map[string]any{
+		"labelBinding": c.labelBinding,
+		"helperText":   c.helperText,
+		"binding":      c.binding,
+	}
+	if c.labelBinding == nil {
+		properties["label"] = c.checkbox.Label
+	}
+	if c.binding == nil {
+		properties["value"] = c.check.Value
+	}
+	return pointerEventValues(properties, c.OnHovered, c.OnHoverEntered,
+		c.OnHoverExited, c.OnPressed, c.OnPressDown, c.OnPressUp)
+}
+
+func (c *CheckBox) Bind(binding types.MutableBinding[bool]) {
 	if c.binding != nil {
 		c.binding.Unwatch(c)
 		c.binding = nil
@@ -81,6 +123,36 @@ func (c *CheckBox) Bind(binding *types.Binding[bool]) {
 
 	c.binding = binding
 	c.binding.Watch(c)
+	c.synced = c.binding.Get()
+}
+
+// BindLabel binds the label of the check box to the given binding.
+func (c *CheckBox) BindLabel(binding types.ValueBinding[string]) {
+	if c.labelBinding != nil {
+		c.labelBinding.Unwatch(c)
+		c.labelBinding = nil
+	}
+
+	if binding == nil {
+		return
+	}
+
+	c.labelBinding = binding
+	c.labelBinding.Watch(c)
+	c.SetLabel(c.labelBinding.Get())
+}
+
+// Dispose stops watching the bindings.
+func (c *CheckBox) Dispose() {
+	c.Bind(nil)
+	c.BindLabel(nil)
+}
+
+// TransferState takes over the value of the old check box, when unbound.
+func (c *CheckBox) TransferState(old types.UIElement) {
+	if o, ok := old.(*CheckBox); ok && c.binding == nil {
+		c.check.Value = o.check.Value
+	}
 }
 
 func (c CheckBox) Value() bool {
@@ -91,6 +163,21 @@ func (c CheckBox) Label() string {
 	return c.checkbox.Label
 }
 
+// Err returns the error reported by the binding, e.g. when the value is
+// invalid.
+func (c CheckBox) Err() error {
+	return bindingError(c.binding)
+}
+
+func (c CheckBox) HelperText() string {
+	return c.helperText
+}
+
+func (c *CheckBox) SetHelperText(helperText string) {
+	c.helperText = helperText
+	c.Wnd().Invalidate()
+}
+
 func (c *CheckBox) SetLabel(label string) {
 	c.checkbox.Label = label
 	c.Wnd().Invalidate()
@@ -102,8 +189,10 @@ func (c *CheckBox) SetValue(value bool) {
 }
 
 func (c *CheckBox) HandleEvents(ctx types.Context) {
-	if c.binding != nil {
-		c.binding.Set(c.check.Value)
+	// Only write back changes, so changes of the binding are not overwritten
+	if c.binding != nil && c.check.Value != c.synced {
+		c.synced = c.check.Value
+		c.binding.Set(c.synced)
 	}
 
 	pressed := c.check.Pressed()
@@ -133,11 +222,22 @@ func (c *CheckBox) HandleEvents(ctx types.Context) {
 }
 
 func (c *CheckBox) Draw(gtx giolayout.Context) giolayout.Dimensions {
-	return c.checkbox.Layout(gtx)
+	checkbox := *c.checkbox
+	err := c.Err()
+	if err != nil {
+		checkbox.Color = errorColor
+		checkbox.IconColor = errorColor
+	}
+	return drawWithHelperText(gtx, c.Wnd().Theme(), c.helperText, err, checkbox.Layout)
 }
 
 func (c *CheckBox) BindingChanged(binding types.Bindable) {
-	if bnd, ok := binding.(*types.Binding[bool]); ok {
-		c.SetValue(bnd.Get())
+	if c.labelBinding != nil && binding == c.labelBinding {
+		c.SetLabel(c.labelBinding.Get())
+		return
+	}
+	if bnd, ok := binding.(types.ValueBinding[bool]); ok {
+		c.synced = bnd.Get()
+		c.SetValue(c.synced)
 	}
 }
@@ -11,7 +11,9 @@ import (
 )
 
 func init() {
-	definition.RegisterUIElement((*Graphic)(nil), newGraphicFromDefinition)
+	definition.RegisterUIElement((*Graphic)(nil), newGraphicFromDefinition,
+		definition.FunctionProperty[GraphicFn]("drawFunction").AsRequired(),
+	)
 }
 
 // Graphic is a widget that draws on the screen by a user supplied draw function.
@@ -46,6 +48,14 @@ func newGraphicFromDefinition(
 	return NewGraphic(ctx, drawFn, id), nil
 }
 
+// Properties returns the properties of the graphic as written in a
+// definition (see definition.Serializable).
+func (g *Graphic) Properties() map[string]any {
+	return map[string]any{
+		"drawFunction": g.drawFn,
+	}
+}
+
 func (g *Graphic) HandleEvents(ctx types.Context) {
 }
 
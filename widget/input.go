@@ -18,7 +18,23 @@ import (
 )
 
 func init() {
-	definition.RegisterUIElement((*Input)(nil), newInputFromDefinition)
+	definition.RegisterUIElement((*Input)(nil), newInputFromDefinition,
+		definition.ConstantProperty[text.Alignment]("alignment"),
+		definition.StringProperty("hint").BindsTo("hintBinding"),
+		definition.BindingProperty[types.ValueBinding[string]]("hintBinding"),
+		definition.StringProperty("helperText"),
+		definition.BoolProperty("multiLine"),
+		definition.BoolProperty("readOnly"),
+		definition.BoolProperty("submit"),
+		definition.StringProperty("mask"),
+		definition.NumberProperty("maxLen"),
+		definition.EnumProperty("inputType", "Any", "Text", "Numeric",
+			"Integer", "Email", "URL", "Telephone", "Password"),
+		wrapPolicyProperty("wrapPolicy"),
+		definition.FunctionProperty[InputFilterFn]("filterCallback"),
+		definition.BindingProperty[types.MutableBinding[string]]("binding"),
+		definition.FunctionProperty[types.BindingAdapter[string]]("converter"),
+	)
 }
 
 type InputType uint
@@ -164,7 +180,11 @@ func InputTypeFromString(s string) InputType {
 //	type: widget.Input
 //	id: <string>				# id of the element (used to get a reference to it in code)
 //	alignment: <string>			# input alignment ("Start", "End", "Middle")
-//	hint: <string>				# input hint
+//	hint: <string>				# input hint, a binding expression or a
+//								# translated message like "@name" (see
+//								# definition.IsTranslation)
+//	hintBinding: <string>		# hint binding reference (will be requested throught the view)
+//	helperText: <string>		# text shown below the input (replaced by the error when the bound value is invalid)
 //	singleLine: <bool>			# single line input
 //	readOnly: <bool>			# read only input
 //	submit: <bool>				# submit input
@@ -174,16 +194,20 @@ func InputTypeFromString(s string) InputType {
 //	wrapPolicy: <string>		# input wrap policy ("WrapHeuristically", "WrapWords", "WrapGraphemes")
 //	filterCallback: <string>	# input filter callback function to adjust the filter based on the current input
 //	binding: <string>			# binding reference (will be requested throught the view)
+//	converter: <string>			# converter reference (will be requested throught the view), allows binding to non-string values
 type Input struct {
 	*Widget
 
 	editor *material.EditorStyle
 	input  widget.Editor
 
-	binding *types.Binding[string]
+	binding     types.MutableBinding[string]
+	hintBinding types.ValueBinding[string]
+	synced      string // Text last read from or written to the binding
 
 	inputType     InputType
 	inputFilterFn InputFilterFn
+	helperText    string
 }
 
 func NewInput(ctx types.Context, hint string, id ...string) *Input {
@@ -202,6 +226,7 @@ func newInputFromDefinition(
 	id, _ := definition.MapValueString[string](data, "id")
 	alignment, _ := definition.GioConstantFromMap[text.Alignment](data, "alignment")
 	hint, _ := definition.MapValueString[string](data, "hint")
+	helperText, _ := definition.MapValueString[string](data, "helperText")
 	multiLine, _ := definition.MapValueBool[bool](data, "multiLine")
 	readOnly, _ := definition.MapValueBool[bool](data, "readOnly")
 	submit, _ := definition.MapValueBool[bool](data, "submit")
@@ -220,6 +245,7 @@ func newInputFromDefinition(
 	i.inputType = InputTypeFromString(inputType)
 	i.input.InputHint = i.inputType.InputHint()
 	i.inputFilterFn = inputFilterFn
+	i.helperText = helperText
 
 	if i.inputFilterFn != nil {
 		i.input.Filter = i.inputFilterFn(ctx, i, "")
@@ -235,15 +261,45 @@ func newInputFromDefinition(
 		i.input.Mask = i.inputType.DefaultMaskRune()
 	}
 
-	if binding, ok := definition.BindingFromMap[*types.Binding[string]](
-		ctx, data, "binding",
+	if binding, ok := definition.ConvertedBindingFromMap[string](
+		ctx, data, "binding", "converter",
 	); ok {
 		i.Bind(binding)
 	}
+	if binding, ok := definition.BindingFromMap[types.ValueBinding[string]](
+		ctx, data, "hintBinding",
+	); ok {
+		i.BindHint(binding)
+	}
 	return i, nil
 }
 
-func (i *Input) Bind(binding *types.Binding[string]) {
+// Properties returns the properties of the input as written in a definition
+// (see definition.Serializable).
+func (i *Input) Properties() map[string]any {
+	properties := map[string]any{
+		"alignment":      i.input.Alignment,
+		"hintBinding":    i.hintBinding,
+		"helperText":     i.helperText,
+		"multiLine":      !i.input.SingleLine,
+		"readOnly":       i.input.ReadOnly,
+		"submit":         i.input.Submit,
+		"maxLen":         i.input.MaxLen,
+		"inputType":      i.inputType,
+		"wrapPolicy":     wrapPolicyName(i.input.WrapPolicy),
+		"filterCallback": i.inputFilterFn,
+		"binding":        i.binding,
+	}
+	if i.hintBinding == nil {
+		properties["hint"] = i.editor.Hint
+	}
+	if i.input.Mask != i.inputType.DefaultMaskRune() {
+		properties["mask"] = string(i.input.Mask)
+	}
+	return properties
+}
+
+func (i *Input) Bind(binding types.MutableBinding[string]) {
 	if i.binding != nil {
 		i.binding.Unwatch(i)
 		i.binding = nil
@@ -255,7 +311,47 @@ func (i *Input) Bind(binding *types.Binding[string]) {
 
 	i.binding = binding
 	i.binding.Watch(i)
-	i.SetText(i.binding.Get())
+	i.synced = i.binding.Get()
+	i.SetText(i.synced)
+}
+
+// BindHint binds the hint of the input to the given binding.
+func (i *Input) BindHint(binding types.ValueBinding[string]) {
+	if i.hintBinding != nil {
+		i.hintBinding.Unwatch(i)
+		i.hintBinding = nil
+	}
+
+	if binding == nil {
+		return
+	}
+
+	i.hintBinding = binding
+	i.hintBinding.Watch(i)
+	i.SetHint(i.hintBinding.Get())
+}
+
+// Dispose stops watching the bindings.
+func (i *Input) Dispose() {
+	i.Bind(nil)
+	i.BindHint(nil)
+}
+
+// TransferState takes over the text (when unbound) and the selection of the
+// old input.
+func (i *Input) TransferState(old types.UIElement) {
+	o, ok := old.(*Input)
+	if !ok {
+		return
+	}
+	if i.binding == nil {
+		i.input.SetText(o.input.Text())
+	}
+	i.input.SetCaret(o.input.Selection())
+}
+
+func (i Input) Hint() string {
+	return i.editor.Hint
 }
 
 func (i Input) SingleLine() bool {
@@ -294,6 +390,16 @@ func (i Input) Text() string {
 	return i.input.Text()
 }
 
+// Err returns the error reported by the binding, e.g. when the text could not
+// be converted to the type of the bound value or is invalid.
+func (i Input) Err() error {
+	return bindingError(i.binding)
+}
+
+func (i Input) HelperText() string {
+	return i.helperText
+}
+
 func (i Input) AsInteger() (int, error) {
 	return strconv.Atoi(i.Text())
 }
@@ -302,6 +408,11 @@ func (i Input) AsFloat() (float64, error) {
 	return strconv.ParseFloat(i.Text(), 64)
 }
 
+func (i *Input) SetHint(hint string) {
+	i.editor.Hint = hint
+	i.Wnd().Invalidate()
+}
+
 func (i *Input) SetSignleLine(singleLine bool) {
 	i.input.SingleLine = singleLine
 }
@@ -334,19 +445,34 @@ func (i *Input) SetWrapPolicy(wrapPolicy text.WrapPolicy) {
 	i.input.WrapPolicy = wrapPolicy
 }
 
+func (i *Input) SetHelperText(helperText string) {
+	i.helperText = helperText
+	i.Wnd().Invalidate()
+}
+
 func (i *Input) SetText(text string) {
 	i.input.SetText(text)
 	i.Wnd().Invalidate()
 }
 
 func (i *Input) HandleEvents(ctx types.Context) {
+	// Apply the edits of this frame before writing them back
+	gtx := ctx.Gtx()
+	for {
+		if _, ok := i.input.Update(gtx); !ok {
+			break
+		}
+	}
+
 	txt := i.input.Text()
 	if i.inputFilterFn != nil {
 		i.input.Filter = i.inputFilterFn(ctx, i, txt)
 	} else {
 		i.input.Filter = i.inputType.AdjustedFilter(txt)
 	}
-	if i.binding != nil {
+	// Only write back edits, so changes of the binding are not overwritten
+	if i.binding != nil && txt != i.synced {
+		i.synced = txt
 		i.binding.Set(txt)
 	}
 }
@@ -357,6 +483,10 @@ func (i *Input) Draw(gtx giolayout.Context) giolayout.Dimensions {
 		CornerRadius: unit.Dp(3),
 		Width:        unit.Dp(2),
 	}
+	err := i.Err()
+	if err != nil {
+		border.Color = errorColor
+	}
 	inset := giolayout.Inset{
 		Top:    unit.Dp(3),
 		Bottom: unit.Dp(3),
@@ -364,17 +494,26 @@ func (i *Input) Draw(gtx giolayout.Context) giolayout.Dimensions {
 		Right:  unit.Dp(3),
 	}
 
-	return border.Layout(gtx, func(gtx giolayout.Context) giolayout.Dimensions {
-		return inset.Layout(gtx, i.editor.Layout)
-	})
+	return drawWithHelperText(gtx, i.Wnd().Theme(), i.helperText, err,
+		func(gtx giolayout.Context) giolayout.Dimensions {
+			return border.Layout(gtx, func(gtx giolayout.Context) giolayout.Dimensions {
+				return inset.Layout(gtx, i.editor.Layout)
+			})
+		})
 }
 
 func (i *Input) BindingChanged(binding types.Bindable) {
-	if bnd, ok := binding.(*types.Binding[string]); ok {
-		if i.input.Text() != bnd.Get() {
-			i.input.SetText(bnd.Get())
-			i.Wnd().Invalidate()
+	if i.hintBinding != nil && binding == i.hintBinding {
+		i.SetHint(i.hintBinding.Get())
+		return
+	}
+	if bnd, ok := binding.(types.ValueBinding[string]); ok {
+		i.synced = bnd.Get()
+		if i.input.Text() != i.synced {
+			i.input.SetText(i.synced)
 		}
+		// Also redraw when only the error state changed
+		i.Wnd().Invalidate()
 	}
 }
 
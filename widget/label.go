@@ -3,6 +3,7 @@
 package widget
 
 import (
+	giofont "gioui.org/font"
 	giolayout "gioui.org/layout"
 	"gioui.org/text"
 	"gioui.org/unit"
@@ -111,15 +112,35 @@ func LabelFormatFromString(s string) LabelFormat {
 }
 
 func init() {
-	definition.RegisterUIElement((*Label)(nil), newLabelFromDefinition)
+	definition.RegisterUIElement((*Label)(nil), newLabelFromDefinition,
+		definition.StringProperty("text").BindsTo("binding"),
+		definition.EnumProperty("labelFormat", Text.String(), H1.String(),
+			H2.String(), H3.String(), H4.String(), H5.String(), H6.String(),
+			Subtitle1.String(), Subtitle2.String(), Body1.String(),
+			Body2.String(), Caption.String(), Overline.String()),
+		definition.ConstantProperty[text.Alignment]("alignment"),
+		definition.NumberProperty("maxLines"),
+		wrapPolicyProperty("wrapPolicy"),
+		definition.StringProperty("truncator"),
+		definition.NumberProperty("lineHeight"),
+		definition.NumberProperty("lineHeightScale"),
+		definition.StringProperty("font"),
+		definition.ConstantProperty[giofont.Style]("fontStyle"),
+		definition.StringProperty("fontWeight"),
+		definition.ColorProperty("color"),
+		definition.ColorProperty("selectionColor"),
+		definition.BindingProperty[types.ValueBinding[string]]("binding"),
+	)
 }
 
 type Label struct {
 	*Widget
 
-	label *material.LabelStyle
+	label  *material.LabelStyle
+	format LabelFormat
+	font   string // Font file the font was loaded from
 
-	binding *types.Binding[string]
+	binding types.ValueBinding[string]
 }
 
 func NewLabel(ctx types.Context, txt string, format LabelFormat, id ...string) *Label {
@@ -127,6 +148,7 @@ func NewLabel(ctx types.Context, txt string, format LabelFormat, id ...string) *
 	l.Widget = NewWidget(ctx.Window(), id...)
 	label := format.initializer()(ctx.Window().Theme(), txt)
 	l.label = &label
+	l.format = format
 
 	return l
 }
@@ -149,6 +171,7 @@ func newLabelFromDefinition(
 
 	if font, ok := definition.MapValueFont(ctx, data, "font", "fontStyle", "fontWeight"); ok {
 		l.label.Font = font.Font
+		l.font, _ = definition.MapValueString[string](data, "font")
 	}
 
 	if color, ok := definition.MapValueColor(data, "color"); ok {
@@ -165,7 +188,7 @@ func newLabelFromDefinition(
 	l.label.LineHeight = lineHeight
 	l.label.LineHeightScale = lineHeightScale
 
-	if binding, ok := definition.BindingFromMap[*types.Binding[string]](
+	if binding, ok := definition.BindingFromMap[types.ValueBinding[string]](
 		ctx, data, "binding",
 	); ok {
 		l.Bind(binding)
@@ -174,7 +197,41 @@ func newLabelFromDefinition(
 	return l, nil
 }
 
-func (l *Label) Bind(binding *types.Binding[string]) {
+// Properties returns the properties of the label as written in a definition
+// (see definition.Serializable). The colors are left out when they are the
+// colors of the label format.
+func (l *Label) Properties() map[string]any {
+	properties := map[string]any{
+		"labelFormat":     l.format,
+		"alignment":       l.label.Alignment,
+		"maxLines":        l.label.MaxLines,
+		"wrapPolicy":      wrapPolicyName(l.label.WrapPolicy),
+		"truncator":       l.label.Truncator,
+		"lineHeight":      l.label.LineHeight,
+		"lineHeightScale": l.label.LineHeightScale,
+		"binding":         l.binding,
+	}
+	if l.binding == nil {
+		properties["text"] = l.label.Text
+	}
+	if l.font != "" {
+		properties["font"] = l.font
+		properties["fontStyle"] = l.label.Font.Style
+		properties["fontWeight"] = l.label.Font.Weight
+	}
+	defaults := l.format.initializer()(l.Wnd().Theme(), "")
+	if l.label.Color != defaults.Color {
+		properties["color"] = l.label.Color
+	}
+	if l.label.SelectionColor != defaults.SelectionColor {
+		properties["selectionColor"] = l.label.SelectionColor
+	}
+	return properties
+}
+
+// Bind binds the text of the label to the given binding. Changes made with
+// SetText are written back to the binding when it is a MutableBinding.
+func (l *Label) Bind(binding types.ValueBinding[string]) {
 	if l.binding != nil {
 		l.binding.Unwatch(l)
 		l.binding = nil
@@ -186,7 +243,13 @@ func (l *Label) Bind(binding *types.Binding[string]) {
 
 	l.binding = binding
 	l.binding.Watch(l)
-	l.SetText(l.binding.Get())
+	l.label.Text = l.binding.Get()
+	l.Wnd().Invalidate()
+}
+
+// Dispose stops watching the binding.
+func (l *Label) Dispose() {
+	l.Bind(nil)
 }
 
 func (l Label) Text() string {
@@ -197,9 +260,14 @@ func (l Label) TextSize() unit.Sp {
 	return l.label.TextSize
 }
 
+// SetText sets the text of the label and writes it back to the binding
+// when it is a MutableBinding.
 func (l *Label) SetText(txt string) {
 	l.label.Text = txt
 	l.Wnd().Invalidate()
+	if bnd, ok := l.binding.(types.MutableBinding[string]); ok {
+		bnd.Set(txt)
+	}
 }
 
 func (l *Label) SetTextSize(size unit.Sp) {
@@ -207,18 +275,14 @@ func (l *Label) SetTextSize(size unit.Sp) {
 	l.Wnd().Invalidate()
 }
 
-func (l *Label) HandleEvents(ctx types.Context) {
-	if l.binding != nil {
-		l.binding.Set(l.Text())
-	}
-}
+func (l *Label) HandleEvents(ctx types.Context) {}
 
 func (l *Label) Draw(gtx giolayout.Context) giolayout.Dimensions {
 	return l.label.Layout(gtx)
 }
 
 func (l *Label) BindingChanged(binding types.Bindable) {
-	if bnd, ok := binding.(*types.Binding[string]); ok {
+	if bnd, ok := binding.(types.ValueBinding[string]); ok {
 		if l.label.Text != bnd.Get() {
 			l.label.Text = bnd.Get()
 			l.Wnd().Invalidate()
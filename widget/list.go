@@ -3,16 +3,48 @@
 package widget
 
 import (
+	"fmt"
+	"slices"
+
 	giolayout "gioui.org/layout"
 	"gioui.org/widget"
+	"gioui.org/widget/material"
 	"github.com/mheremans/goui/definition"
 	"github.com/mheremans/goui/types"
 )
 
 func init() {
-	definition.RegisterUIElement((*List)(nil), newListFromDefinition)
+	definition.RegisterUIElement((*List)(nil), newListFromDefinition,
+		definition.ConstantProperty[giolayout.Axis]("axis"),
+		definition.ConstantProperty[giolayout.Alignment]("alignment"),
+		definition.FunctionProperty[ListItemEventHandlerFn]("itemEventHandler"),
+		definition.FunctionProperty[ListItemRendererFn]("itemRenderer"),
+		definition.BoolProperty("scrollToEnd"),
+		definition.BindingProperty[types.BindableList]("binding"),
+		definition.TemplateProperty("template"),
+	)
 }
 
+// List is a scrollable list of the items of a list binding
+//
+// Yaml	definition:
+//
+//	type: widget.List
+//	id: <string>				# id of the element (used to get a reference to
+//								# it in code)
+//	axis: <string>				# Horizontal or Vertical
+//	alignment: <string>			# alignment of the items across the axis
+//	binding: <string>			# list binding reference (will be requested
+//								# throught the view)
+//	scrollToEnd: <bool>			# keep the list scrolled to the end
+//	itemRenderer: <string>		# function drawing an item (will be requested
+//								# throught the view)
+//	itemEventHandler: <string>	# function handling the events of an item
+//								# (will be requested throught the view)
+//	template: <element>			# element created for every item, instead of
+//								# an itemRenderer. In the template, a binding
+//								# reference starting with a dot refers to a
+//								# field of the item (e.g. `binding: .Name`)
 type List struct {
 	*Widget
 
@@ -22,9 +54,20 @@ type List struct {
 	itemEventHandler ListItemEventHandlerFn
 	itemRenderer     ListItemRendererFn
 
+	template map[string]any
+	rows     []*listRow
+	defCtx   types.Context // Context the list was created with
+
 	ctx types.Context
 }
 
+// listRow holds the elements created from the template for an item.
+type listRow struct {
+	item *types.ItemBinding
+	def  *definition.Definition
+	err  error // Error creating the elements, shown instead of them
+}
+
 func NewList(
 	ctx types.Context,
 	axis giolayout.Axis,
@@ -35,6 +78,7 @@ func NewList(
 ) *List {
 	l := new(List)
 	l.ctx = ctx
+	l.defCtx = ctx
 	l.Widget = NewWidget(ctx.Window(), id...)
 	l.list.Axis = axis
 	l.list.Alignment = alignment
@@ -56,6 +100,9 @@ func newListFromDefinition(
 
 	i := NewList(ctx, axis, alignment, itemEventHandler, itemRenderer, id)
 	i.list.ScrollToEnd = scrollToEnd
+	if template, ok := data["template"].(map[string]any); ok {
+		i.SetTemplate(template)
+	}
 
 	if binding, ok := definition.BindingFromMap[types.BindableList](
 		ctx, data, "binding",
@@ -66,11 +113,27 @@ func newListFromDefinition(
 	return i, nil
 }
 
+// Properties returns the properties of the list as written in a definition
+// (see definition.Serializable).
+func (l *List) Properties() map[string]any {
+	return map[string]any{
+		"axis":             l.list.Axis,
+		"alignment":        l.list.Alignment,
+		"itemEventHandler": l.itemEventHandler,
+		"itemRenderer":     l.itemRenderer,
+		"scrollToEnd":      l.list.ScrollToEnd,
+		"binding":          l.binding,
+		"template":         l.template,
+	}
+}
+
 func (l *List) Bind(binding types.BindableList) {
 	if l.binding != nil {
 		l.binding.Unwatch(l)
 		l.binding = nil
 	}
+	l.disposeRows(0, len(l.rows))
+	l.rows = nil
 
 	if binding == nil {
 		return
@@ -80,6 +143,27 @@ func (l *List) Bind(binding types.BindableList) {
 	l.binding.Watch(l)
 }
 
+// SetTemplate sets the definition of the element created for every item,
+// which replaces the item renderer. The elements are created when the item is
+// drawn for the first time, in an item context (see definition.ItemContext).
+func (l *List) SetTemplate(template map[string]any) {
+	l.disposeRows(0, len(l.rows))
+	l.rows = nil
+	l.template = template
+}
+
+// Dispose stops watching the binding and disposes the elements of the items.
+func (l *List) Dispose() {
+	l.Bind(nil)
+}
+
+// TransferState takes over the scroll position of the old list.
+func (l *List) TransferState(old types.UIElement) {
+	if o, ok := old.(*List); ok {
+		l.list.Position = o.list.Position
+	}
+}
+
 func (l List) Axis() giolayout.Axis {
 	return l.list.Axis
 }
@@ -104,21 +188,197 @@ func (l *List) SetScrollToEnd(scrollToEnd bool) {
 	l.list.ScrollToEnd = scrollToEnd
 }
 
+// Err returns the error creating the elements of an item from the template,
+// or nil if all items drawn so far were created.
+func (l List) Err() error {
+	for _, row := range l.rows {
+		if row != nil && row.err != nil {
+			return row.err
+		}
+	}
+	return nil
+}
+
 func (l *List) HandleEvents(ctx types.Context) {
 	// Cache this cycles context, so we can use it in the Draw, where we handle
-	// the events of the list items.
+	// the events of the item event handler.
 	l.ctx = ctx
+
+	// The handlers can change the list, so the rows are indexed on every
+	// iteration
+	for i := 0; i < len(l.rows); i++ {
+		if row := l.rows[i]; row != nil && row.def != nil {
+			types.HandleElementEvents(ctx, row.def.Root())
+		}
+	}
 }
 
 func (l *List) Draw(gtx giolayout.Context) giolayout.Dimensions {
-	return l.list.Layout(gtx, l.binding.Size(), func(gtx giolayout.Context, index int) giolayout.Dimensions {
-		l.itemEventHandler(l.ctx, index, l.binding)
+	if l.binding == nil {
+		return giolayout.Dimensions{}
+	}
+	if l.template != nil {
+		l.syncRows()
+	}
+	size := l.binding.Size()
+	return l.list.Layout(gtx, size, func(gtx giolayout.Context, index int) giolayout.Dimensions {
+		if l.itemEventHandler != nil {
+			l.itemEventHandler(l.ctx, index, l.binding)
+			if index >= l.binding.Size() {
+				// The handler removed the item
+				return giolayout.Dimensions{}
+			}
+		}
+		if l.template != nil {
+			return l.drawRow(gtx, index)
+		}
 		return l.itemRenderer(gtx, index, l.binding)
 	})
 }
 
+func (l *List) drawRow(gtx giolayout.Context, index int) giolayout.Dimensions {
+	if index >= len(l.rows) {
+		// The rows were changed while laying out the list
+		l.syncRows()
+		if index >= len(l.rows) {
+			return giolayout.Dimensions{}
+		}
+	}
+	row := l.row(index)
+	if row.err != nil {
+		label := material.Caption(l.Wnd().Theme(), row.err.Error())
+		label.Color = errorColor
+		return label.Layout(gtx)
+	}
+	return types.DrawElement(gtx, row.def.Root())
+}
+
+// row returns the row of the item at index, creating its elements if needed.
+// When the elements can not be created, the error is kept in the row, so it
+// is not tried again for every frame.
+func (l *List) row(index int) *listRow {
+	if l.rows[index] != nil {
+		return l.rows[index]
+	}
+
+	item := types.NewItemBinding(l.binding, index)
+	def, err := definition.NewFromMap(definition.ItemContext(l.defCtx, item), l.template)
+	if err != nil {
+		def = nil
+		err = fmt.Errorf("failed to create item %d of list %s: %w", index, l.ID(), err)
+	}
+	l.rows[index] = &listRow{item: item, def: def, err: err}
+	return l.rows[index]
+}
+
+// syncRows makes sure there is a row for every item of the bound list. The
+// rows follow the changes of the list (see updateRows), but a change that
+// is still being dispatched to the event loop can leave them behind.
+func (l *List) syncRows() {
+	size := l.binding.Size()
+	if len(l.rows) > size {
+		l.disposeRows(size, len(l.rows))
+		l.rows = l.rows[:size]
+	}
+	if len(l.rows) < size {
+		l.rows = append(l.rows, make([]*listRow, size-len(l.rows))...)
+	}
+}
+
+// disposeRows disposes the elements of the rows from start to end.
+func (l *List) disposeRows(start int, end int) {
+	for _, row := range l.rows[max(start, 0):min(end, len(l.rows))] {
+		if row != nil && row.def != nil {
+			row.def.Dispose()
+		}
+	}
+}
+
+// updateRows keeps the rows in sync with a change of the bound list. Rows are
+// reused as long as their item stays in the list.
+func (l *List) updateRows(change types.ListChange) {
+	clamp := func(i int) int {
+		return min(max(i, 0), len(l.rows))
+	}
+
+	switch change.Kind {
+	case types.ListInsert:
+		index := clamp(change.Index)
+		l.rows = slices.Insert(l.rows, index, make([]*listRow, change.Count)...)
+	case types.ListRemove:
+		start, end := clamp(change.Index), clamp(change.Index+change.Count)
+		l.disposeRows(start, end)
+		l.rows = slices.Delete(l.rows, start, end)
+	case types.ListMove:
+		if change.Index < len(l.rows) && change.To < len(l.rows) {
+			row := l.rows[change.Index]
+			l.rows = slices.Delete(l.rows, change.Index, change.Index+1)
+			l.rows = slices.Insert(l.rows, change.To, row)
+		}
+	case types.ListReplace:
+		for _, row := range l.rows[clamp(change.Index):clamp(change.Index+change.Count)] {
+			if row != nil {
+				row.item.Changed()
+			}
+		}
+		return
+	case types.ListReset:
+		if change.Count < len(l.rows) {
+			l.disposeRows(change.Count, len(l.rows))
+			l.rows = l.rows[:change.Count]
+		}
+		for _, row := range l.rows {
+			if row != nil {
+				row.item.Changed()
+			}
+		}
+		return
+	}
+
+	for i, row := range l.rows {
+		if row != nil {
+			row.item.SetIndex(i)
+		}
+	}
+}
+
 func (l *List) BindingChanged(binding types.Bindable) {
 	if _, ok := binding.(types.BindableList); ok {
 		l.Wnd().Invalidate()
 	}
 }
+
+// ListBindingChanged updates the scroll position for an incremental change
+// of the bound list, so the items currently in view stay in view.
+func (l *List) ListBindingChanged(binding types.BindableList, change types.ListChange) {
+	if l.template != nil {
+		l.updateRows(change)
+	}
+
+	pos := &l.list.Position
+	switch change.Kind {
+	case types.ListInsert:
+		if change.Index < pos.First {
+			pos.First += change.Count
+		}
+	case types.ListRemove:
+		switch {
+		case change.Index+change.Count <= pos.First:
+			pos.First -= change.Count
+		case change.Index < pos.First:
+			// The first visible item was removed
+			pos.First = change.Index
+			pos.Offset = 0
+		}
+	case types.ListMove:
+		switch {
+		case change.Index == pos.First:
+			pos.First = change.To
+		case change.Index < pos.First && change.To >= pos.First:
+			pos.First--
+		case change.Index > pos.First && change.To <= pos.First:
+			pos.First++
+		}
+	}
+	l.Wnd().Invalidate()
+}
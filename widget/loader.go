@@ -12,7 +12,9 @@ import (
 )
 
 func init() {
-	definition.RegisterUIElement((*Loader)(nil), newLoaderFromDefinition)
+	definition.RegisterUIElement((*Loader)(nil), newLoaderFromDefinition,
+		definition.ColorProperty("color"),
+	)
 }
 
 type Loader struct {
@@ -43,6 +45,17 @@ func newLoaderFromDefinition(
 	return l, nil
 }
 
+// Properties returns the properties of the loader as written in a definition
+// (see definition.Serializable). The color is left out when it is the color
+// of the theme.
+func (l *Loader) Properties() map[string]any {
+	properties := map[string]any{}
+	if l.loader.Color != material.Loader(l.Wnd().Theme()).Color {
+		properties["color"] = l.loader.Color
+	}
+	return properties
+}
+
 func (l Loader) Color() color.NRGBA {
 	return l.loader.Color
 }
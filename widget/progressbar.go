@@ -10,7 +10,11 @@ import (
 )
 
 func init() {
-	definition.RegisterUIElement((*ProgressBar)(nil), newProgressBarFromDefinition)
+	definition.RegisterUIElement((*ProgressBar)(nil), newProgressBarFromDefinition,
+		definition.NumberProperty("value").BindsTo("binding"),
+		definition.BindingProperty[types.ValueBinding[float32]]("binding"),
+		definition.FunctionProperty[types.BindingAdapter[float32]]("converter"),
+	)
 }
 
 // ProgressBar is a widget that displays a progress bar.
@@ -19,13 +23,15 @@ func init() {
 //
 //	type: widget.ProgressBar
 //	id: <string>		# id of the element (used to get a reference to it in code)
-//	value: <number>		# initial value of the progress bar (in range 0.0 - 1.0)
+//	value: <number>		# initial value of the progress bar (in range 0.0 - 1.0),
+//						# or a binding expression like "{Done / Total}"
 //	binding: <string>	# binding reference (will be requested throught the view)
+//	converter: <string>	# converter reference (will be requested throught the view), allows binding to non-float32 values
 type ProgressBar struct {
 	*Widget
 
 	progressBar *material.ProgressBarStyle
-	binding     *types.Binding[float32]
+	binding     types.ValueBinding[float32]
 }
 
 // NewProgressBar creates a new ProgressBar widget with the specified context
@@ -53,13 +59,31 @@ func newProgressBarFromDefinition(
 	id, _ := definition.MapValueString[string](data, "id")
 	value, _ := definition.MapValueFloat[float32](data, "value")
 	pb := NewProgressBar(ctx, value, id)
-	if binding, ok := definition.BindingFromMap[*types.Binding[float32]](ctx, data, "binding"); ok {
+	if _, ok := data["converter"]; ok {
+		if binding, ok := definition.ConvertedBindingFromMap[float32](ctx, data, "binding", "converter"); ok {
+			pb.Bind(binding)
+		}
+	} else if binding, ok := definition.BindingFromMap[types.ValueBinding[float32]](ctx, data, "binding"); ok {
 		pb.Bind(binding)
 	}
 	return pb, nil
 }
 
-func (p *ProgressBar) Bind(binding *types.Binding[float32]) {
+// Properties returns the properties of the progress bar as written in a
+// definition (see definition.Serializable).
+func (p *ProgressBar) Properties() map[string]any {
+	properties := map[string]any{
+		"binding": p.binding,
+	}
+	if p.binding == nil {
+		properties["value"] = p.progressBar.Progress
+	}
+	return properties
+}
+
+// Bind binds the progress of the bar to the given binding. Changes made with
+// SetValue are written back to the binding when it is a MutableBinding.
+func (p *ProgressBar) Bind(binding types.ValueBinding[float32]) {
 	if p.binding != nil {
 		p.binding.Unwatch(p)
 		p.binding = nil
@@ -71,7 +95,20 @@ func (p *ProgressBar) Bind(binding *types.Binding[float32]) {
 
 	p.binding = binding
 	p.binding.Watch(p)
-	p.SetValue(p.binding.Get())
+	p.progressBar.Progress = p.binding.Get()
+	p.Wnd().Invalidate()
+}
+
+// Dispose stops watching the binding.
+func (p *ProgressBar) Dispose() {
+	p.Bind(nil)
+}
+
+// TransferState takes over the value of the old progress bar, when unbound.
+func (p *ProgressBar) TransferState(old types.UIElement) {
+	if o, ok := old.(*ProgressBar); ok && p.binding == nil {
+		p.progressBar.Progress = o.progressBar.Progress
+	}
 }
 
 // Value returns the current value of the ProgressBar.
@@ -79,18 +116,18 @@ func (p ProgressBar) Value() float32 {
 	return p.progressBar.Progress
 }
 
-// SetValue sets the value of the ProgressBar.
+// SetValue sets the value of the ProgressBar and writes it back to the
+// binding when it is a MutableBinding.
 func (p *ProgressBar) SetValue(value float32) {
 	p.progressBar.Progress = value
 	p.Wnd().Invalidate()
+	if bnd, ok := p.binding.(types.MutableBinding[float32]); ok {
+		bnd.Set(value)
+	}
 }
 
 // HandleEvents handles the events for the ProgressBar widget.
-func (p *ProgressBar) HandleEvents(ctx types.Context) {
-	if p.binding != nil {
-		p.binding.Set(p.progressBar.Progress)
-	}
-}
+func (p *ProgressBar) HandleEvents(ctx types.Context) {}
 
 // Draw renders the ProgressBar widget on the provided giolayout.Context.
 //
@@ -104,7 +141,8 @@ func (p *ProgressBar) Draw(gtx giolayout.Context) giolayout.Dimensions {
 }
 
 func (p *ProgressBar) BindingChanged(binding types.Bindable) {
-	if bnd, ok := binding.(*types.Binding[float32]); ok {
-		p.SetValue(bnd.Get())
+	if bnd, ok := binding.(types.ValueBinding[float32]); ok {
+		p.progressBar.Progress = bnd.Get()
+		p.Wnd().Invalidate()
 	}
 }
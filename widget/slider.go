@@ -11,7 +11,13 @@ import (
 )
 
 func init() {
-	definition.RegisterUIElement((*Slider)(nil), newSliderFromDefinition)
+	definition.RegisterUIElement((*Slider)(nil), newSliderFromDefinition,
+		definition.ConstantProperty[giolayout.Axis]("axis"),
+		definition.ColorProperty("color"),
+		definition.StringProperty("helperText"),
+		definition.BindingProperty[types.MutableBinding[float32]]("binding"),
+		definition.FunctionProperty[types.BindingAdapter[float32]]("converter"),
+	)
 }
 
 type Slider struct {
@@ -20,7 +26,9 @@ type Slider struct {
 	slider *material.SliderStyle
 	float  widget.Float
 
-	binding *types.Binding[float32]
+	binding    types.MutableBinding[float32]
+	synced     float32 // Value last read from or written to the binding
+	helperText string
 }
 
 func NewSlider(ctx types.Context, id ...string) *Slider {
@@ -39,18 +47,31 @@ func newSliderFromDefinition(
 	id, _ := definition.MapValueString[string](data, "id")
 	axis, _ := definition.GioConstantFromMap[giolayout.Axis](data, "axis")
 	color, _ := definition.MapValueColor(data, "color")
+	helperText, _ := definition.MapValueString[string](data, "helperText")
 
 	slider := NewSlider(ctx, id)
 	slider.slider.Axis = axis
 	slider.slider.Color = color
+	slider.helperText = helperText
 
-	if binding, ok := definition.BindingFromMap[*types.Binding[float32]](ctx, data, "binding"); ok {
+	if binding, ok := definition.ConvertedBindingFromMap[float32](ctx, data, "binding", "converter"); ok {
 		slider.Bind(binding)
 	}
 	return slider, nil
 }
 
-func (s *Slider) Bind(binding *types.Binding[float32]) {
+// Properties returns the properties of the slider as written in a definition
+// (see definition.Serializable).
+func (s *Slider) Properties() map[string]any {
+	return map[string]any{
+		"axis":       s.slider.Axis,
+		"color":      s.slider.Color,
+		"helperText": s.helperText,
+		"binding":    s.binding,
+	}
+}
+
+func (s *Slider) Bind(binding types.MutableBinding[float32]) {
 	if s.binding != nil {
 		s.binding.Unwatch(s)
 		s.binding = nil
@@ -62,33 +83,70 @@ func (s *Slider) Bind(binding *types.Binding[float32]) {
 
 	s.binding = binding
 	s.binding.Watch(s)
-	s.SetValue(s.binding.Get())
+	s.synced = s.binding.Get()
+	s.SetValue(s.synced)
+}
+
+// Dispose stops watching the binding.
+func (s *Slider) Dispose() {
+	s.Bind(nil)
+}
+
+// TransferState takes over the value of the old slider, when unbound.
+func (s *Slider) TransferState(old types.UIElement) {
+	if o, ok := old.(*Slider); ok && s.binding == nil {
+		s.float.Value = o.float.Value
+	}
 }
 
 func (s Slider) Value() float32 {
 	return s.float.Value
 }
 
+// Err returns the error reported by the binding, e.g. when the value is
+// invalid.
+func (s Slider) Err() error {
+	return bindingError(s.binding)
+}
+
+func (s Slider) HelperText() string {
+	return s.helperText
+}
+
+func (s *Slider) SetHelperText(helperText string) {
+	s.helperText = helperText
+	s.Wnd().Invalidate()
+}
+
 func (s *Slider) SetValue(value float32) {
 	s.float.Value = value
 	s.Wnd().Invalidate()
 }
 
 func (s *Slider) HandleEvents(ctx types.Context) {
-	if s.binding != nil {
-		s.binding.Set(s.float.Value)
+	// Only write back changes, so changes of the binding are not overwritten
+	if s.binding != nil && s.float.Value != s.synced {
+		s.synced = s.float.Value
+		s.binding.Set(s.synced)
 	}
 }
 
 func (s *Slider) Draw(gtx giolayout.Context) giolayout.Dimensions {
-	return s.slider.Layout(gtx)
+	slider := *s.slider
+	err := s.Err()
+	if err != nil {
+		slider.Color = errorColor
+	}
+	return drawWithHelperText(gtx, s.Wnd().Theme(), s.helperText, err, slider.Layout)
 }
 
 func (s *Slider) BindingChanged(binding types.Bindable) {
-	if bnd, ok := binding.(*types.Binding[float32]); ok {
-		if s.slider.Float.Value != bnd.Get() {
-			s.slider.Float.Value = bnd.Get()
-			s.Wnd().Invalidate()
+	if bnd, ok := binding.(types.ValueBinding[float32]); ok {
+		s.synced = bnd.Get()
+		if s.slider.Float.Value != s.synced {
+			s.slider.Float.Value = s.synced
 		}
+		// Also redraw when only the error state changed
+		s.Wnd().Invalidate()
 	}
 }
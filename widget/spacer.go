@@ -10,7 +10,10 @@ import (
 )
 
 func init() {
-	definition.RegisterUIElement((*Spacer)(nil), newSpacerFromDefinition)
+	definition.RegisterUIElement((*Spacer)(nil), newSpacerFromDefinition,
+		definition.NumberProperty("width"),
+		definition.NumberProperty("height"),
+	)
 }
 
 // Spacer is a widget that can be used to add spacing between other widgets.
@@ -52,6 +55,15 @@ func newSpacerFromDefinition(
 	return NewSpacer(ctx, types.NewSpacerSize(width, height), id), nil
 }
 
+// Properties returns the properties of the spacer as written in a
+// definition (see definition.Serializable).
+func (s *Spacer) Properties() map[string]any {
+	return map[string]any{
+		"width":  s.spacer.Width,
+		"height": s.spacer.Height,
+	}
+}
+
 // HandleEvents handles the events for the Spacer widget.
 //
 // Parameters:
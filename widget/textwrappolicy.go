@@ -2,10 +2,21 @@
 
 package widget
 
-import "gioui.org/text"
+import (
+	"gioui.org/text"
+	"github.com/mheremans/goui/definition"
+)
+
+// wrapPolicyProperty is the definition property of a text wrap policy.
+func wrapPolicyProperty(name string) definition.Property {
+	return definition.EnumProperty(name,
+		"WrapHeuristically", "WrapWords", "WrapGraphemes")
+}
 
 func gioTextWrapPolicyFromString(policy string) text.WrapPolicy {
 	switch policy {
+	case "WrapHeuristically":
+		return text.WrapHeuristically
 	case "WrapWords":
 		return text.WrapWords
 	case "WrapGraphemes":
@@ -14,3 +25,16 @@ func gioTextWrapPolicyFromString(policy string) text.WrapPolicy {
 		return text.WrapGraphemes
 	}
 }
+
+// wrapPolicyName returns the name of a text wrap policy in a definition, or
+// an empty string for the default policy.
+func wrapPolicyName(policy text.WrapPolicy) string {
+	switch policy {
+	case text.WrapHeuristically:
+		return "WrapHeuristically"
+	case text.WrapWords:
+		return "WrapWords"
+	default:
+		return ""
+	}
+}
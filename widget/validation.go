@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: MIT
+
+package widget
+
+import (
+	"image/color"
+
+	giolayout "gioui.org/layout"
+	"gioui.org/widget/material"
+	"github.com/mheremans/goui/types"
+)
+
+// errorColor is used to draw widgets bound to an invalid value
+var errorColor = color.NRGBA{R: 211, G: 47, B: 47, A: 255}
+
+// bindingError returns the error reported by a binding (e.g. a validation or
+// conversion error), or nil if the binding reports no error.
+func bindingError(binding any) error {
+	if reporter, ok := binding.(types.ErrorReporter); ok {
+		return reporter.Err()
+	}
+	return nil
+}
+
+// drawWithHelperText draws the widget with the helper text below it. When err
+// is not nil, the error is shown instead of the helper text.
+func drawWithHelperText(
+	gtx giolayout.Context,
+	theme *material.Theme,
+	helperText string,
+	err error,
+	w giolayout.Widget,
+) giolayout.Dimensions {
+	helper := material.Caption(theme, helperText)
+	if err != nil {
+		helper.Text = err.Error()
+		helper.Color = errorColor
+	}
+	if helper.Text == "" {
+		return w(gtx)
+	}
+
+	return giolayout.Flex{Axis: giolayout.Vertical}.Layout(gtx,
+		giolayout.Rigid(w),
+		giolayout.Rigid(helper.Layout),
+	)
+}
@@ -8,13 +8,16 @@ import (
 )
 
 type Widget struct {
+	*types.Conditions
+
 	wnd types.Window
 	id  string
 }
 
 func NewWidget(wnd types.Window, id ...string) *Widget {
 	return &Widget{
-		wnd: wnd,
+		Conditions: types.NewConditions(wnd),
+		wnd:        wnd,
 		id: func() string {
 			if len(id) == 0 {
 				return uuid.NewString()
@@ -37,6 +40,7 @@ func (w Widget) ID() string {
 
 func (w *Widget) SetWnd(wnd types.Window) {
 	w.wnd = wnd
+	w.Conditions.SetWnd(wnd)
 }
 
 func (w *Widget) SetID(id string) {
@@ -10,6 +10,7 @@ import (
 	"gioui.org/app"
 	"gioui.org/op"
 	"gioui.org/widget/material"
+	"github.com/mheremans/goui/i18n"
 	"github.com/mheremans/goui/types"
 
 	_ "github.com/mheremans/goui/layout"
@@ -24,9 +25,11 @@ type Window struct {
 	initState *windowInitState // Temporary settings cache used to initialize the window
 	closeChan CloseChan        // Channel that will notify when the window is closed
 
-	w     *app.Window // The gio window
-	theme *material.Theme
-	op    op.Ops
+	w          *app.Window // The gio window
+	theme      *material.Theme
+	op         op.Ops
+	dispatcher *dispatcher // Queues binding notifications for the event loop
+	localizer  *i18n.Localizer
 
 	newView         types.View // The new view to render (replaces the old view)
 	view            types.View // The view to render
@@ -40,9 +43,11 @@ type Window struct {
 // It returns a pointer to the newly created Window.
 func NewWindow(title string) *Window {
 	wnd := &Window{
-		closeChan: make(CloseChan),
-		w:         new(app.Window),
-		theme:     material.NewTheme(),
+		closeChan:  make(CloseChan),
+		w:          new(app.Window),
+		theme:      material.NewTheme(),
+		dispatcher: newDispatcher(),
+		localizer:  i18n.NewLocalizer(nil, nil),
 	}
 	wnd.initState = &windowInitState{}
 	wnd.initState.title = &title
@@ -158,12 +163,33 @@ func (wnd *Window) SetFullscreen(fullscreen bool) {
 
 // SetScreen sets the view for the Window.
 //
-// This method swaps out the original view for the new view.
+// This method swaps out the original view for the new view, which is
+// destroyed. Applications with several views show a Navigator instead, which
+// keeps the views on a back-stack.
 func (wnd *Window) SetView(view types.View) {
 	wnd.newView = view
 	wnd.viewInitialized = false
 }
 
+// SetTranslations sets the message catalogs used to translate the strings of
+// the definitions (see definition.New). It should be called before the view is
+// shown, views keep the translations they were created with.
+func (wnd *Window) SetTranslations(bundle *i18n.Bundle) {
+	wnd.localizer = i18n.NewLocalizer(bundle, wnd.localizer.Locale())
+}
+
+// Locale returns the binding holding the current locale of the Window. It
+// initially holds the locale of the user (see i18n.DetectLocale). Setting it
+// updates all translated strings.
+func (wnd Window) Locale() *types.Binding[string] {
+	return wnd.localizer.Locale()
+}
+
+// Localizer returns the localizer translating the strings of the definitions.
+func (wnd Window) Localizer() *i18n.Localizer {
+	return wnd.localizer
+}
+
 // Theme returns the material theme associated with the Window.
 func (wnd Window) Theme() *material.Theme {
 	return wnd.theme
@@ -173,6 +199,18 @@ func (wnd Window) Invalidate() {
 	wnd.w.Invalidate()
 }
 
+// Dispatch queues fn to be executed on the event loop goroutine.
+//
+// It is safe to call from any goroutine. Queued functions are executed at the
+// start of the next frame, before the view handles its events, and again
+// before the view is drawn, so functions queued while handling the events
+// take effect in the same frame. Bindings use
+// this to notify the UI elements watching them, so view models can update
+// their state from background goroutines.
+func (wnd Window) Dispatch(fn func()) {
+	wnd.dispatcher.push(fn)
+}
+
 // Show shows the window with the given view.
 //
 // It takes a View parameter and returns a CloseChan and an error.
@@ -199,6 +237,7 @@ func (wnd *Window) Show(view types.View) (CloseChan, error) {
 
 		wnd.initState.initWindow(wnd)
 		wnd.initState = nil
+		wnd.dispatcher.start(wnd.w.Invalidate)
 
 		err := wnd.eventLoop()
 		if err != nil {
@@ -231,8 +270,14 @@ func (wnd *Window) eventLoop() error {
 				return err
 			}
 
+			// Deliver binding notifications queued by other goroutines
+			wnd.dispatcher.drain()
+
 			if wnd.view != nil {
 				wnd.view.HandleEvents(ctx)
+				// Deliver the notifications of the changes made while
+				// handling the events
+				wnd.dispatcher.drain()
 				wnd.view.DrawView(ctx)
 			}
 			e.Frame(ctx.Gtx().Ops)